@@ -0,0 +1,44 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package aurora
+
+import (
+	"strings"
+)
+
+// AlarmInfo describes an alarm as the service manual does, see AlarmState.Info
+type AlarmInfo struct {
+	Alarm    AlarmState `json:"alarm"`
+	Code     string     `json:"code,omitempty"` // As shown on the display such as E018 or W003, empty if it has none
+	Severity Severity   `json:"severity"`
+	Warning  bool       `json:"warning"` // A warning rather than an error
+	Cause    string     `json:"cause"`
+	Action   string     `json:"action"`
+}
+
+// String returns the code and description of the alarm
+func (a *AlarmInfo) String() string {
+	if a.Code == "" {
+		return a.Alarm.String()
+	}
+	text := strings.Fields(strings.Replace(a.Alarm.String(), a.Code, "", 1))
+	return a.Code + " " + strings.Join(text, " ")
+}
+
+// Info returns what the alarm means, its display code and what to do about it.
+// Unknown alarms are errors with nothing more known about them.
+func (a AlarmState) Info() *AlarmInfo {
+	info, ok := alarmStateInfo[a]
+	if !ok {
+		info.Severity = SeverityError
+	}
+	info.Alarm = a
+	if info.Code != "" {
+		info.Warning = strings.HasPrefix(info.Code, "W")
+	} else {
+		info.Warning = info.Severity > SeverityNone && info.Severity < SeverityError
+	}
+	return &info
+}
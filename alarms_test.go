@@ -0,0 +1,55 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package aurora_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/freman/go-aurora"
+)
+
+func TestAlarmInfo(t *testing.T) {
+	tests := []struct {
+		alarm    aurora.AlarmState
+		code     string
+		severity aurora.Severity
+		warning  bool
+		str      string
+	}{
+		{aurora.AlarmNone, "", aurora.SeverityNone, false, "No Alarm"},
+		{aurora.AlarmGridFail, "W003", aurora.SeverityWarning, true, "W003 Grid Fail"},
+		{aurora.AlarmE009, "E009", aurora.SeverityError, false, "E009 Internal error"},
+		{aurora.AlarmGroundFault18, "E018", aurora.SeverityCritical, false, "E018 Ground Fault (18)"},
+		{aurora.AlarmDCDCFail25, "E012", aurora.SeverityError, false, "E012 Dc/Dc Fail (25)"},
+		{aurora.AlarmBatteryLow, "", aurora.SeverityWarning, true, "Battery Low"},
+		{aurora.AlarmState(99), "", aurora.SeverityError, false, "Unknown AlarmState(99)"},
+	}
+
+	for _, test := range tests {
+		info := test.alarm.Info()
+		if info.Alarm != test.alarm || info.Code != test.code || info.Severity != test.severity || info.Warning != test.warning || info.String() != test.str {
+			t.Errorf("Unexpected info for %v: %+v (%s)", test.alarm, info, info)
+		}
+		if test.alarm != aurora.AlarmState(99) && (info.Cause == "" || info.Action == "") {
+			t.Errorf("Expected a cause and action for %v", test.alarm)
+		}
+	}
+
+	// The alarm counts towards the severity of the state
+	state := &aurora.State{Global: aurora.GSRun, Inverter: aurora.ISRun, Channel1: aurora.DCDCMPPT, Channel2: aurora.DCDCMPPT, Alarm: aurora.AlarmRisoLow}
+	if state.Severity() != aurora.SeverityCritical {
+		t.Errorf("Expected %v got %v", aurora.SeverityCritical, state.Severity())
+	}
+
+	data, err := json.Marshal(aurora.AlarmGridFail.Info())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded aurora.AlarmInfo
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded != *aurora.AlarmGridFail.Info() {
+		t.Errorf("Expected %+v got %+v (%v)", aurora.AlarmGridFail.Info(), decoded, err)
+	}
+}
@@ -6,109 +6,257 @@ package aurora
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
-	"errors"
-	"fmt"
 	"io"
+	"sync"
 	"time"
 )
 
-// Inverter structure for connecting to an inverter
+// Inverter structure for connecting to an inverter.
+// An Inverter created directly talks to Conn, one returned by Bus.Inverter shares
+// the connection of the bus with every other handle. Either way it is safe for
+// concurrent use as whole transactions are serialised. Changing Conn of one
+// created directly starts afresh on the new connection, with its own Stats.
 type Inverter struct {
 	Conn    io.ReadWriter
 	Address byte
-}
 
-// ErrCRCFailure is returned whenever the data read in from the serial port might
-// have been corrupted en route and no longer matches the crc
-var ErrCRCFailure = errors.New("CRC Failure")
+	// Retry is the policy for retrying failed exchanges, when nil that of the
+	// bus is used. A standalone Inverter makes a single attempt by default.
+	Retry *RetryPolicy
+
+	mu      sync.Mutex
+	bus     *Bus
+	private bool // bus wraps Conn for this Inverter alone
+}
 
 // Communicate encodes and transmits given commands returning a response having
-// checked the CRC and transmission state if applicable
+// checked the CRC and transmission state if applicable. The command must be
+// known and given the arguments it takes, otherwise it isn't transmitted and an
+// *ArgumentError is the cause.
+// Any error returned is an *InverterError wrapping the cause
 func (i *Inverter) Communicate(command Command, args ...Argument) ([]byte, error) {
-	outputBuffer := outputPayload{
-		Payload: [8]byte{i.Address, byte(command), 32, 32, 32, 32, 32, 32},
+	return i.CommunicateContext(context.Background(), command, args...)
+}
+
+// CommunicateContext works much like Communicate but gives up once the context
+// is done, failing with ErrTimeout if its deadline passed or the context error if
+// it was cancelled. Failed exchanges are retried as per the RetryPolicy.
+// Blocking reads and writes can only be interrupted when Conn implements
+// SetReadDeadline/SetWriteDeadline (as net.Conn and *os.File do), otherwise the
+// context is only checked between transmitting and receiving. When it does, any
+// deadline already set on Conn is replaced by that of the context.
+func (i *Inverter) CommunicateContext(ctx context.Context, command Command, args ...Argument) ([]byte, error) {
+	if _, err := validate(command, args); err != nil {
+		return nil, i.newError(ctx, command, err)
 	}
-	lastIndex := 1
-	for index, arg := range args {
-		if index > 5 {
-			break
+
+	return i.retry(ctx, command, args, func(bus *Bus) ([]byte, error) {
+		return i.communicate(ctx, bus, command, args...)
+	})
+}
+
+// Raw transmits a request for any command byte, known or not, without checking
+// the arguments, returning the response frame as is for the caller to make sense
+// of. It is meant for exploring the protocol, beware that an unknown command may
+// well change the settings of the inverter.
+func (i *Inverter) Raw(command Command, args ...Argument) (*ResponseFrame, error) {
+	return i.RawContext(context.Background(), command, args...)
+}
+
+// RawContext works much like Raw but gives up once the context is done. Only
+// frames that fail to arrive intact are retried as per the RetryPolicy.
+func (i *Inverter) RawContext(ctx context.Context, command Command, args ...Argument) (*ResponseFrame, error) {
+	frame, err := i.retry(ctx, command, args, func(bus *Bus) ([]byte, error) {
+		return i.transmit(ctx, bus, command, args...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var response ResponseFrame
+	copy(response.Payload[:], frame)
+	return &response, nil
+}
+
+// retry makes attempts at an exchange as per the RetryPolicy, a request that
+// changes state in the inverter only gets the one
+func (i *Inverter) retry(ctx context.Context, command Command, args []Argument, exchange func(bus *Bus) ([]byte, error)) ([]byte, error) {
+	bus := i.link()
+	policy := i.Retry
+	if policy == nil {
+		policy = bus.Retry
+	}
+	if writes(command, args) {
+		policy = nil
+	}
+
+	bus.count(func(s *Stats) { s.Requests++ })
+	for attempt := 1; ; attempt++ {
+		result, err := i.attempt(ctx, bus, command, exchange)
+		if err == nil {
+			return result, nil
 		}
-		lastIndex = index + 2
-		outputBuffer.Payload[lastIndex] = arg.Byte()
+
+		err.Attempts = attempt
+		if !policy.wait(ctx, attempt, err) {
+			bus.count(func(s *Stats) { s.Failures++ })
+			return nil, err
+		}
+		bus.count(func(s *Stats) { s.Retries++ })
 	}
+}
 
-	// Inverter expects 0 terminated instructions
-	if lastIndex < 7 {
-		outputBuffer.Payload[lastIndex+1] = 0
+// writes returns true if the request changes state in the inverter, such as
+// setting the time or resetting the partial counter. The inverter may well have
+// acted on one whose response was lost, so sending it again isn't safe.
+func writes(command Command, args []Argument) bool {
+	if spec, ok := LookupCommand(command); ok && spec.Writes {
+		return true
 	}
+	return command == GetCounters && len(args) > 0 && args[0] == CounterReset
+}
 
-	outputBuffer.CRC = calculateCRC(outputBuffer.Payload[:])
+// attempt makes a single exchange with the inverter having waited for the bus
+func (i *Inverter) attempt(ctx context.Context, bus *Bus, command Command, exchange func(bus *Bus) ([]byte, error)) ([]byte, *InverterError) {
+	if err := ctx.Err(); err != nil {
+		return nil, i.newError(ctx, command, err)
+	}
 
-	if err := binary.Write(i.Conn, binary.LittleEndian, outputBuffer); err != nil {
-		return nil, err
+	if err := bus.acquire(ctx); err != nil {
+		return nil, i.newError(ctx, command, err)
 	}
+	defer bus.release()
+
+	bus.drain(ctx)
+
+	stop := bus.watch(ctx)
+	defer stop()
+
+	bus.count(func(s *Stats) { s.Attempts++ })
+	result, err := exchange(bus)
+	if err != nil {
+		return nil, i.newError(ctx, command, err)
+	}
+	return result, nil
+}
+
+// Stats returns a snapshot of the counters for the bus the inverter communicates
+// over, these are shared by every handle on the bus
+func (i *Inverter) Stats() Stats {
+	return i.link().Stats()
+}
+
+// link returns the bus the inverter communicates over, a standalone Inverter
+// gets a private one wrapping Conn on first use and whenever Conn is changed
+func (i *Inverter) link() *Bus {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.bus == nil || i.private && i.bus.Conn != i.Conn {
+		i.bus = &Bus{Conn: i.Conn}
+		i.private = true
+	}
+	return i.bus
+}
 
-	inputBuffer := inputPayload{}
-	if err := binary.Read(i.Conn, binary.LittleEndian, &inputBuffer); err != nil {
+// communicate exchanges frames with the inverter, returning the body of the response
+func (i *Inverter) communicate(ctx context.Context, bus *Bus, command Command, args ...Argument) ([]byte, error) {
+	frame, err := i.transmit(ctx, bus, command, args...)
+	if err != nil {
 		return nil, err
 	}
 
-	if crc := calculateCRC(inputBuffer.Payload[:]); crc != inputBuffer.CRC {
-		return nil, ErrCRCFailure
+	var response ResponseFrame
+	copy(response.Payload[:], frame)
+
+	body, err := response.Body(command)
+	if state, ok := err.(TransmissionState); ok {
+		return nil, &InverterError{Address: i.Address, Command: command, State: state, Frame: frame, Err: state}
 	}
 
-	if command == GetPartNumber || command == GetSerialNumber {
-		return inputBuffer.Payload[:], nil
+	return body, nil
+}
+
+// transmit sends the request returning the response frame once its CRC has been checked
+func (i *Inverter) transmit(ctx context.Context, bus *Bus, command Command, args ...Argument) ([]byte, error) {
+	request, _ := NewRequestFrame(i.Address, command, args...).MarshalBinary()
+	if _, err := bus.Conn.Write(request); err != nil {
+		return nil, err
 	}
 
-	if inputBuffer.Payload[0] != 0 {
-		return nil, errors.New(TransmissionState(inputBuffer.Payload[0]).String())
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	if command == GetState {
-		return inputBuffer.Payload[1:], nil
+	frame, err := bus.readResponse(ctx, request)
+	if err != nil {
+		return nil, err
 	}
 
-	return inputBuffer.Payload[2:], nil
+	var response ResponseFrame
+	if err := response.UnmarshalBinary(frame); err != nil {
+		return nil, &InverterError{Address: i.Address, Command: command, Frame: frame, Err: err}
+	}
+	return frame, nil
 }
 
 // CommunicateVar works much like Communicate but expects an interface to write the response to
 func (i *Inverter) CommunicateVar(v interface{}, command Command, args ...Argument) error {
-	result, err := i.Communicate(command, args...)
+	return i.CommunicateVarContext(context.Background(), v, command, args...)
+}
+
+// CommunicateVarContext works much like CommunicateVar but gives up once the context is done
+func (i *Inverter) CommunicateVarContext(ctx context.Context, v interface{}, command Command, args ...Argument) error {
+	result, err := i.CommunicateContext(ctx, command, args...)
 	if err != nil {
 		return err
 	}
-	return binary.Read(bytes.NewReader(result), binary.BigEndian, v)
+	return decodeVar(result, v)
 }
 
-func calculateCRC(input []byte) uint16 {
-	crc := uint16(0xffff)
-	for _, chr := range input {
-		for i, data := 0, chr; i < 8; i, data = i+1, data>>1 {
-			if (crc&0x0001)^uint16(data&0x01) == 1 {
-				crc = (crc >> 1) ^ 0x8408
-			} else {
-				crc = crc >> 1
-			}
-		}
+// Query sends any known command returning the response as decoded by the
+// description of the command, see ResponseFrame.Decode for the types returned
+func (i *Inverter) Query(command Command, args ...Argument) (interface{}, error) {
+	return i.QueryContext(context.Background(), command, args...)
+}
+
+// QueryContext works much like Query but gives up once the context is done
+func (i *Inverter) QueryContext(ctx context.Context, command Command, args ...Argument) (interface{}, error) {
+	result, err := i.CommunicateContext(ctx, command, args...)
+	if err != nil {
+		return nil, err
 	}
 
-	return ^crc
+	value, err := decodeBody(command, args, result)
+	if err != nil {
+		return nil, i.newError(ctx, command, err)
+	}
+	return value, nil
 }
 
 // CommCheck calls the simplest command supported by the inverter "GetVersion" just
 // as a quick check to make sure it's connected and working.
-// You might want to wrap a deadline around this call.
+// You might want to use CommCheckContext to put a deadline on this call.
 func (i *Inverter) CommCheck() error {
-	_, err := i.Communicate(GetVersion)
+	return i.CommCheckContext(context.Background())
+}
+
+// CommCheckContext works much like CommCheck but gives up once the context is done
+func (i *Inverter) CommCheckContext(ctx context.Context) error {
+	_, err := i.CommunicateContext(ctx, GetVersion)
 	return err
 }
 
 // State returns the current state for the inverter
 func (i *Inverter) State() (*State, error) {
+	return i.StateContext(context.Background())
+}
+
+// StateContext works much like State but gives up once the context is done
+func (i *Inverter) StateContext(ctx context.Context) (*State, error) {
 	var state State
-	err := i.CommunicateVar(&state, GetState)
+	err := i.CommunicateVarContext(ctx, &state, GetState)
 	if err != nil {
 		return nil, err
 	}
@@ -118,8 +266,13 @@ func (i *Inverter) State() (*State, error) {
 
 // Last4Alarms returns the last 4 alarm states
 func (i *Inverter) Last4Alarms() ([]AlarmState, error) {
+	return i.Last4AlarmsContext(context.Background())
+}
+
+// Last4AlarmsContext works much like Last4Alarms but gives up once the context is done
+func (i *Inverter) Last4AlarmsContext(ctx context.Context) ([]AlarmState, error) {
 	alarms := make([]AlarmState, 4)
-	err := i.CommunicateVar(&alarms, GetLast4Alarms)
+	err := i.CommunicateVarContext(ctx, &alarms, GetLast4Alarms)
 	if err != nil {
 		return nil, err
 	}
@@ -128,7 +281,12 @@ func (i *Inverter) Last4Alarms() ([]AlarmState, error) {
 
 // PartNumber returns the inverters part number
 func (i *Inverter) PartNumber() (string, error) {
-	result, err := i.Communicate(GetPartNumber)
+	return i.PartNumberContext(context.Background())
+}
+
+// PartNumberContext works much like PartNumber but gives up once the context is done
+func (i *Inverter) PartNumberContext(ctx context.Context) (string, error) {
+	result, err := i.CommunicateContext(ctx, GetPartNumber)
 	if err != nil {
 		return "", err
 	}
@@ -137,7 +295,12 @@ func (i *Inverter) PartNumber() (string, error) {
 
 // SerialNumber returns the inverters serial number
 func (i *Inverter) SerialNumber() (string, error) {
-	result, err := i.Communicate(GetSerialNumber)
+	return i.SerialNumberContext(context.Background())
+}
+
+// SerialNumberContext works much like SerialNumber but gives up once the context is done
+func (i *Inverter) SerialNumberContext(ctx context.Context) (string, error) {
+	result, err := i.CommunicateContext(ctx, GetSerialNumber)
 	if err != nil {
 		return "", err
 	}
@@ -146,8 +309,13 @@ func (i *Inverter) SerialNumber() (string, error) {
 
 // Version returns the inverters version
 func (i *Inverter) Version() (*Version, error) {
+	return i.VersionContext(context.Background())
+}
+
+// VersionContext works much like Version but gives up once the context is done
+func (i *Inverter) VersionContext(ctx context.Context) (*Version, error) {
 	var version Version
-	if err := i.CommunicateVar(&version, GetVersion); err != nil {
+	if err := i.CommunicateVarContext(ctx, &version, GetVersion); err != nil {
 		return nil, err
 	}
 	return &version, nil
@@ -155,7 +323,12 @@ func (i *Inverter) Version() (*Version, error) {
 
 // ManufactureDate returns the inverters date of manufacture
 func (i *Inverter) ManufactureDate() (string, string, error) {
-	result, err := i.Communicate(GetManufacturingDate)
+	return i.ManufactureDateContext(context.Background())
+}
+
+// ManufactureDateContext works much like ManufactureDate but gives up once the context is done
+func (i *Inverter) ManufactureDateContext(ctx context.Context) (string, string, error) {
+	result, err := i.CommunicateContext(ctx, GetManufacturingDate)
 	if err != nil {
 		return "", "", err
 	}
@@ -166,161 +339,311 @@ func (i *Inverter) ManufactureDate() (string, string, error) {
 
 // FirmwareVersion returns the inverters firmware version
 func (i *Inverter) FirmwareVersion() (string, error) {
-	result, err := i.Communicate(GetFirmwareVersion)
+	return i.FirmwareVersionContext(context.Background())
+}
+
+// FirmwareVersionContext works much like FirmwareVersion but gives up once the context is done
+func (i *Inverter) FirmwareVersionContext(ctx context.Context) (string, error) {
+	result, err := i.CommunicateContext(ctx, GetFirmwareVersion)
 	if err != nil {
 		return "", err
 	}
-	return fmt.Sprintf("%c.%c.%c.%c",
-		rune(result[0]),
-		rune(result[1]),
-		rune(result[2]),
-		rune(result[3]),
-	), nil
+	return decodeFirmware(result), nil
+}
+
+// Flags returns the flags and switches of the inverter
+func (i *Inverter) Flags() (*Flags, error) {
+	return i.FlagsContext(context.Background())
+}
+
+// FlagsContext works much like Flags but gives up once the context is done
+func (i *Inverter) FlagsContext(ctx context.Context) (*Flags, error) {
+	var flags Flags
+	if err := i.CommunicateVarContext(ctx, &flags, GetFlags); err != nil {
+		return nil, err
+	}
+	return &flags, nil
 }
 
 // Configuration returns the current configuration state from the inverter
 func (i *Inverter) Configuration() (ConfigurationState, error) {
-	result, err := i.Communicate(GetConfiguration)
+	return i.ConfigurationContext(context.Background())
+}
+
+// ConfigurationContext works much like Configuration but gives up once the context is done
+func (i *Inverter) ConfigurationContext(ctx context.Context) (ConfigurationState, error) {
+	result, err := i.CommunicateContext(ctx, GetConfiguration)
 	if err != nil {
 		return ConfigurationState(255), err
 	}
 	return ConfigurationState(result[0]), nil
 }
 
-// GetCumulatedEnergy returns the cumulated energy for a given period
-func (i *Inverter) GetCumulatedEnergy(period CumulationPeriod) (uint32, error) {
-	result, err := i.Communicate(GetCumulatedEnergy, period)
+// GetCumulatedEnergy returns the cumulated energy in watt hours for a given period
+func (i *Inverter) GetCumulatedEnergy(period CumulationPeriod) (WattHours, error) {
+	return i.GetCumulatedEnergyContext(context.Background(), period)
+}
+
+// GetCumulatedEnergyContext works much like GetCumulatedEnergy but gives up once the context is done
+func (i *Inverter) GetCumulatedEnergyContext(ctx context.Context, period CumulationPeriod) (WattHours, error) {
+	result, err := i.CommunicateContext(ctx, GetCumulatedEnergy, period)
 	if err != nil {
 		return 0, err
 	}
-	return binary.BigEndian.Uint32(result), nil
+	return WattHours(binary.BigEndian.Uint32(result)), nil
 }
 
 // DailyEnergy returns the daily cumulated energy
-func (i *Inverter) DailyEnergy() (uint32, error) {
+func (i *Inverter) DailyEnergy() (WattHours, error) {
 	return i.GetCumulatedEnergy(CumulatedDaily)
 }
 
+// DailyEnergyContext works much like DailyEnergy but gives up once the context is done
+func (i *Inverter) DailyEnergyContext(ctx context.Context) (WattHours, error) {
+	return i.GetCumulatedEnergyContext(ctx, CumulatedDaily)
+}
+
 // WeeklyEnergy returns the weekly cumulated energy
-func (i *Inverter) WeeklyEnergy() (uint32, error) {
+func (i *Inverter) WeeklyEnergy() (WattHours, error) {
 	return i.GetCumulatedEnergy(CumulatedWeekly)
 }
 
+// WeeklyEnergyContext works much like WeeklyEnergy but gives up once the context is done
+func (i *Inverter) WeeklyEnergyContext(ctx context.Context) (WattHours, error) {
+	return i.GetCumulatedEnergyContext(ctx, CumulatedWeekly)
+}
+
+// Last7DaysEnergy returns the energy cumulated over the last 7 days
+func (i *Inverter) Last7DaysEnergy() (WattHours, error) {
+	return i.GetCumulatedEnergy(CumulatedLast7Days)
+}
+
+// Last7DaysEnergyContext works much like Last7DaysEnergy but gives up once the context is done
+func (i *Inverter) Last7DaysEnergyContext(ctx context.Context) (WattHours, error) {
+	return i.GetCumulatedEnergyContext(ctx, CumulatedLast7Days)
+}
+
 // MonthlyEnergy returns the monthly cumulated energy
-func (i *Inverter) MonthlyEnergy() (uint32, error) {
+func (i *Inverter) MonthlyEnergy() (WattHours, error) {
 	return i.GetCumulatedEnergy(CumulatedMonthly)
 }
 
+// MonthlyEnergyContext works much like MonthlyEnergy but gives up once the context is done
+func (i *Inverter) MonthlyEnergyContext(ctx context.Context) (WattHours, error) {
+	return i.GetCumulatedEnergyContext(ctx, CumulatedMonthly)
+}
+
 // YearlyEnergy returns the yearly cumulated energy
-func (i *Inverter) YearlyEnergy() (uint32, error) {
+func (i *Inverter) YearlyEnergy() (WattHours, error) {
 	return i.GetCumulatedEnergy(CumulatedYearly)
 }
 
+// YearlyEnergyContext works much like YearlyEnergy but gives up once the context is done
+func (i *Inverter) YearlyEnergyContext(ctx context.Context) (WattHours, error) {
+	return i.GetCumulatedEnergyContext(ctx, CumulatedYearly)
+}
+
 // TotalEnergy returns the total cumulated energy
-func (i *Inverter) TotalEnergy() (uint32, error) {
+func (i *Inverter) TotalEnergy() (WattHours, error) {
 	return i.GetCumulatedEnergy(CumulatedTotal)
 }
 
+// TotalEnergyContext works much like TotalEnergy but gives up once the context is done
+func (i *Inverter) TotalEnergyContext(ctx context.Context) (WattHours, error) {
+	return i.GetCumulatedEnergyContext(ctx, CumulatedTotal)
+}
+
 // PartialEnergy returns the cumulated energy since last reset
-func (i *Inverter) PartialEnergy() (uint32, error) {
+func (i *Inverter) PartialEnergy() (WattHours, error) {
 	return i.GetCumulatedEnergy(CumulatedPartial)
 }
 
-// GetDSPData returns data for various DSParameters
+// PartialEnergyContext works much like PartialEnergy but gives up once the context is done
+func (i *Inverter) PartialEnergyContext(ctx context.Context) (WattHours, error) {
+	return i.GetCumulatedEnergyContext(ctx, CumulatedPartial)
+}
+
+// GetDSPData returns data for various DSParameters, in the unit given by the
+// parameter's Unit
 func (i *Inverter) GetDSPData(parameter DSParameter) (float32, error) {
+	return i.GetDSPDataContext(context.Background(), parameter)
+}
+
+// GetDSPDataContext works much like GetDSPData but gives up once the context is done
+func (i *Inverter) GetDSPDataContext(ctx context.Context, parameter DSParameter) (float32, error) {
 	var f float32
-	err := i.CommunicateVar(&f, GetDSP, parameter)
+	err := i.CommunicateVarContext(ctx, &f, GetDSP, parameter)
 	return f, err
 }
 
 // Frequency returns the operating frequency
-func (i *Inverter) Frequency() (float32, error) {
-	return i.GetDSPData(DSPFrequency)
+func (i *Inverter) Frequency() (Hertz, error) {
+	return i.FrequencyContext(context.Background())
+}
+
+// FrequencyContext works much like Frequency but gives up once the context is done
+func (i *Inverter) FrequencyContext(ctx context.Context) (Hertz, error) {
+	f, err := i.GetDSPDataContext(ctx, DSPFrequency)
+	return Hertz(f), err
 }
 
 // GridVoltage returns the voltage from the grid
-func (i *Inverter) GridVoltage() (float32, error) {
-	return i.GetDSPData(DSPGridVoltage)
+func (i *Inverter) GridVoltage() (Volts, error) {
+	return i.GridVoltageContext(context.Background())
+}
+
+// GridVoltageContext works much like GridVoltage but gives up once the context is done
+func (i *Inverter) GridVoltageContext(ctx context.Context) (Volts, error) {
+	f, err := i.GetDSPDataContext(ctx, DSPGridVoltage)
+	return Volts(f), err
 }
 
 // GridCurrent returns the amount of current (in amps) being pushed to the grid.
-func (i *Inverter) GridCurrent() (float32, error) {
-	return i.GetDSPData(DSPGridCurrent)
+func (i *Inverter) GridCurrent() (Amps, error) {
+	return i.GridCurrentContext(context.Background())
+}
+
+// GridCurrentContext works much like GridCurrent but gives up once the context is done
+func (i *Inverter) GridCurrentContext(ctx context.Context) (Amps, error) {
+	f, err := i.GetDSPDataContext(ctx, DSPGridCurrent)
+	return Amps(f), err
 }
 
 // GridPower returns the amount of power (in watts) being pushed to the grid.
-func (i *Inverter) GridPower() (float32, error) {
-	return i.GetDSPData(DSPGridPower)
+func (i *Inverter) GridPower() (Watts, error) {
+	return i.GridPowerContext(context.Background())
+}
+
+// GridPowerContext works much like GridPower but gives up once the context is done
+func (i *Inverter) GridPowerContext(ctx context.Context) (Watts, error) {
+	f, err := i.GetDSPDataContext(ctx, DSPGridPower)
+	return Watts(f), err
 }
 
 // Input1Voltage returns the voltage received on input 1 from your solar array/wind turbine
-func (i *Inverter) Input1Voltage() (float32, error) {
-	return i.GetDSPData(DSPInput1Voltage)
+func (i *Inverter) Input1Voltage() (Volts, error) {
+	return i.Input1VoltageContext(context.Background())
+}
+
+// Input1VoltageContext works much like Input1Voltage but gives up once the context is done
+func (i *Inverter) Input1VoltageContext(ctx context.Context) (Volts, error) {
+	f, err := i.GetDSPDataContext(ctx, DSPInput1Voltage)
+	return Volts(f), err
 }
 
 // Input1Current returns the amount of current (in amps) being received from input 1
-func (i *Inverter) Input1Current() (float32, error) {
-	return i.GetDSPData(DSPInput1Current)
+func (i *Inverter) Input1Current() (Amps, error) {
+	return i.Input1CurrentContext(context.Background())
+}
+
+// Input1CurrentContext works much like Input1Current but gives up once the context is done
+func (i *Inverter) Input1CurrentContext(ctx context.Context) (Amps, error) {
+	f, err := i.GetDSPDataContext(ctx, DSPInput1Current)
+	return Amps(f), err
 }
 
 // Input2Voltage returns the voltage received on input 2 from your solar array/wind turbine
-func (i *Inverter) Input2Voltage() (float32, error) {
-	return i.GetDSPData(DSPInput2Voltage)
+func (i *Inverter) Input2Voltage() (Volts, error) {
+	return i.Input2VoltageContext(context.Background())
+}
+
+// Input2VoltageContext works much like Input2Voltage but gives up once the context is done
+func (i *Inverter) Input2VoltageContext(ctx context.Context) (Volts, error) {
+	f, err := i.GetDSPDataContext(ctx, DSPInput2Voltage)
+	return Volts(f), err
 }
 
 // Input2Current returns the amount of current (in amps) being received from input 2
-func (i *Inverter) Input2Current() (float32, error) {
-	return i.GetDSPData(DSPInput2Current)
+func (i *Inverter) Input2Current() (Amps, error) {
+	return i.Input2CurrentContext(context.Background())
+}
+
+// Input2CurrentContext works much like Input2Current but gives up once the context is done
+func (i *Inverter) Input2CurrentContext(ctx context.Context) (Amps, error) {
+	f, err := i.GetDSPDataContext(ctx, DSPInput2Current)
+	return Amps(f), err
 }
 
 // InverterTemperature returns the current temperature of the inverter in celsius
-func (i *Inverter) InverterTemperature() (float32, error) {
-	return i.GetDSPData(DSPInverterTemperature)
+func (i *Inverter) InverterTemperature() (Celsius, error) {
+	return i.InverterTemperatureContext(context.Background())
+}
+
+// InverterTemperatureContext works much like InverterTemperature but gives up once the context is done
+func (i *Inverter) InverterTemperatureContext(ctx context.Context) (Celsius, error) {
+	f, err := i.GetDSPDataContext(ctx, DSPInverterTemperature)
+	return Celsius(f), err
 }
 
 // BoosterTemperature returns the current temperature of the booster in celsius
-func (i *Inverter) BoosterTemperature() (float32, error) {
-	return i.GetDSPData(DSPBoosterTemperature)
+func (i *Inverter) BoosterTemperature() (Celsius, error) {
+	return i.BoosterTemperatureContext(context.Background())
+}
+
+// BoosterTemperatureContext works much like BoosterTemperature but gives up once the context is done
+func (i *Inverter) BoosterTemperatureContext(ctx context.Context) (Celsius, error) {
+	f, err := i.GetDSPDataContext(ctx, DSPBoosterTemperature)
+	return Celsius(f), err
 }
 
-// Joules returns the amount of power produced in the last 10 seconds as Joules
-func (i *Inverter) Joules() (uint16, error) {
+// Joules returns the energy exported in the last 10 seconds
+func (i *Inverter) Joules() (Joules, error) {
+	return i.JoulesContext(context.Background())
+}
+
+// JoulesContext works much like Joules but gives up once the context is done
+func (i *Inverter) JoulesContext(ctx context.Context) (Joules, error) {
 	var s uint16
-	err := i.CommunicateVar(&s, GetLast10SecEnergy)
-	return s, err
+	err := i.CommunicateVarContext(ctx, &s, GetLast10SecEnergy)
+	return Joules(s), err
 }
 
 // GetTime returns the current timestamp from the inverter, returns as a unix epoch based timestamp
 func (i *Inverter) GetTime() (time.Time, error) {
-	result, err := i.Communicate(GetTime)
+	return i.GetTimeContext(context.Background())
+}
+
+// GetTimeContext works much like GetTime but gives up once the context is done
+func (i *Inverter) GetTimeContext(ctx context.Context) (time.Time, error) {
+	result, err := i.CommunicateContext(ctx, GetTime)
 	if err != nil {
 		return time.Unix(0, 0), err
 	}
-	return time.Unix(int64(InverterEpochOffset+binary.BigEndian.Uint32(result)), 0), nil
+	return decodeTime(result), nil
 }
 
 // SetTime sets the time in the inverter to the given timestamp.
 // Warning: this may result in the resetting of partial counters/cumulaters.
 func (i *Inverter) SetTime(t time.Time) error {
+	return i.SetTimeContext(context.Background(), t)
+}
+
+// SetTimeContext works much like SetTime but gives up once the context is done
+func (i *Inverter) SetTimeContext(ctx context.Context, t time.Time) error {
 	value := uint32(t.Unix() - InverterEpochOffset)
 	buf := new(bytes.Buffer)
 	binary.Write(buf, binary.BigEndian, value)
 	bvalue := buf.Bytes()
-	_, err := i.Communicate(SetTime, Byte(bvalue[0]), Byte(bvalue[1]), Byte(bvalue[2]), Byte(bvalue[3]))
+	_, err := i.CommunicateContext(ctx, SetTime, Byte(bvalue[0]), Byte(bvalue[1]), Byte(bvalue[2]), Byte(bvalue[3]))
 	return err
 }
 
 // GetCounterData returns the value (seconds?) from one of the counters being total, partial, grid, and reset runtimes.
 func (i *Inverter) GetCounterData(counter Counter) (uint32, error) {
-	result, err := i.Communicate(GetCounters, counter)
+	return i.GetCounterDataContext(context.Background(), counter)
+}
+
+// GetCounterDataContext works much like GetCounterData but gives up once the context is done
+func (i *Inverter) GetCounterDataContext(ctx context.Context, counter Counter) (uint32, error) {
+	result, err := i.CommunicateContext(ctx, GetCounters, counter)
 	if err != nil {
 		return 0, err
 	}
 	return binary.BigEndian.Uint32(result), nil
 }
 
-func (i *Inverter) getDuration(counter Counter) (time.Duration, error) {
-	result, err := i.GetCounterData(counter)
+func (i *Inverter) getDuration(ctx context.Context, counter Counter) (time.Duration, error) {
+	result, err := i.GetCounterDataContext(ctx, counter)
 	if err != nil {
 		return 0, err
 	}
@@ -329,21 +652,41 @@ func (i *Inverter) getDuration(counter Counter) (time.Duration, error) {
 
 // TotalRunTime returns the total runtime for the inverter
 func (i *Inverter) TotalRunTime() (time.Duration, error) {
-	return i.getDuration(CounterTotal)
+	return i.getDuration(context.Background(), CounterTotal)
+}
+
+// TotalRunTimeContext works much like TotalRunTime but gives up once the context is done
+func (i *Inverter) TotalRunTimeContext(ctx context.Context) (time.Duration, error) {
+	return i.getDuration(ctx, CounterTotal)
 }
 
 // PartialRunTime returns the partial runtime of the inverter...
 func (i *Inverter) PartialRunTime() (time.Duration, error) {
-	return i.getDuration(CounterPartial)
+	return i.getDuration(context.Background(), CounterPartial)
+}
+
+// PartialRunTimeContext works much like PartialRunTime but gives up once the context is done
+func (i *Inverter) PartialRunTimeContext(ctx context.Context) (time.Duration, error) {
+	return i.getDuration(ctx, CounterPartial)
 }
 
 // GridRunTime returns the amount of time the inverter has been on grid
 func (i *Inverter) GridRunTime() (time.Duration, error) {
-	return i.getDuration(CounterGrid)
+	return i.getDuration(context.Background(), CounterGrid)
+}
+
+// GridRunTimeContext works much like GridRunTime but gives up once the context is done
+func (i *Inverter) GridRunTimeContext(ctx context.Context) (time.Duration, error) {
+	return i.getDuration(ctx, CounterGrid)
 }
 
 // ResetRunTime resets the counter
 func (i *Inverter) ResetRunTime() error {
-	_, err := i.GetCounterData(CounterReset)
+	return i.ResetRunTimeContext(context.Background())
+}
+
+// ResetRunTimeContext works much like ResetRunTime but gives up once the context is done
+func (i *Inverter) ResetRunTimeContext(ctx context.Context) error {
+	_, err := i.GetCounterDataContext(ctx, CounterReset)
 	return err
 }
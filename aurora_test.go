@@ -6,13 +6,18 @@ package aurora_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
+	"errors"
 	"io"
+	"io/ioutil"
+	"net"
 	"reflect"
 	"testing"
 	"time"
 
 	"github.com/freman/go-aurora"
+	"github.com/freman/go-aurora/aurorasim"
 )
 
 type mockSerial struct {
@@ -58,26 +63,11 @@ func (m *mockSerial) expect(t *testing.T, in, out []byte) {
 	if err := binary.Write(m, binary.LittleEndian, out); err != nil {
 		t.Error(err)
 	}
-	if err := binary.Write(m, binary.LittleEndian, calculateCRC(out)); err != nil {
+	if err := binary.Write(m, binary.LittleEndian, aurora.CRC(out)); err != nil {
 		t.Error(err)
 	}
 }
 
-func calculateCRC(input []byte) uint16 {
-	crc := uint16(0xffff)
-	for _, chr := range input {
-		for i, data := 0, chr; i < 8; i, data = i+1, data>>1 {
-			if (crc&0x0001)^uint16(data&0x01) == 1 {
-				crc = (crc >> 1) ^ 0x8408
-			} else {
-				crc = crc >> 1
-			}
-		}
-	}
-
-	return ^crc
-}
-
 func makeCRCError(t *testing.T, ttys1 io.ReadWriter) {
 	tmp := make([]byte, 10)
 	c, err := ttys1.Read(tmp)
@@ -89,7 +79,7 @@ func makeCRCError(t *testing.T, ttys1 io.ReadWriter) {
 	}
 	res := []byte{0, 2, 3, 4, 5, 6}
 	binary.Write(ttys1, binary.LittleEndian, res)
-	binary.Write(ttys1, binary.LittleEndian, calculateCRC(res)+1)
+	binary.Write(ttys1, binary.LittleEndian, aurora.CRC(res)+1)
 }
 
 func TestCommunicate(t *testing.T) {
@@ -109,32 +99,21 @@ func TestCommunicate(t *testing.T) {
 
 		res := []byte{0, 2, 3, 4, 5, 6}
 		binary.Write(ttys1, binary.LittleEndian, res)
-		binary.Write(ttys1, binary.LittleEndian, calculateCRC(res))
+		binary.Write(ttys1, binary.LittleEndian, aurora.CRC(res))
 	}()
 	i.Communicate(aurora.GetCumulatedEnergy, aurora.CumulatedMonthly)
 
-	// Push more than 10 bytes through, extra byte should be dropped
-	go func() {
-		tmp := make([]byte, 11)
-		c, err := ttys1.Read(tmp)
-		if err != nil {
-			t.Error(err)
-		}
-		if c != 10 {
-			t.Errorf("Expected %d, got %d", 10, c)
-		}
-
-		res := []byte{0, 2, 3, 4, 5, 6}
-		binary.Write(ttys1, binary.LittleEndian, res)
-		binary.Write(ttys1, binary.LittleEndian, calculateCRC(res))
-	}()
+	// Too many arguments are refused rather than dropped, nothing is sent
 	b := aurora.Byte(0x01)
-	i.Communicate(aurora.GetCumulatedEnergy, b, b, b, b, b, b, b)
+	_, err := i.Communicate(aurora.GetCumulatedEnergy, b, b, b, b, b, b, b)
+	if !errors.Is(err, aurora.ErrArgumentCount) {
+		t.Errorf("Expected %v got %v", aurora.ErrArgumentCount, err)
+	}
 
 	// Push a bad CRC in the response
 	go makeCRCError(t, ttys1)
-	_, err := i.Communicate(aurora.GetCumulatedEnergy, aurora.CumulatedMonthly)
-	if err != aurora.ErrCRCFailure {
+	_, err = i.Communicate(aurora.GetCumulatedEnergy, aurora.CumulatedMonthly)
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 
@@ -151,11 +130,22 @@ func TestCommunicate(t *testing.T) {
 
 		res := []byte{52, 2, 3, 4, 5, 6}
 		binary.Write(ttys1, binary.LittleEndian, res)
-		binary.Write(ttys1, binary.LittleEndian, calculateCRC(res))
+		binary.Write(ttys1, binary.LittleEndian, aurora.CRC(res))
 	}()
 	_, err = i.Communicate(aurora.GetCumulatedEnergy, aurora.CumulatedMonthly)
-	if err == nil {
-		t.Error("Expected error")
+	if !errors.Is(err, aurora.TSVariableDoesNotExist) {
+		t.Errorf("Expected %v got %v", aurora.TSVariableDoesNotExist, err)
+	}
+
+	var ierr *aurora.InverterError
+	if !errors.As(err, &ierr) {
+		t.Fatalf("Expected *aurora.InverterError got %T", err)
+	}
+	if ierr.Command != aurora.GetCumulatedEnergy || ierr.State != aurora.TSVariableDoesNotExist || len(ierr.Frame) != 8 {
+		t.Errorf("Unexpected error details: %#v", ierr)
+	}
+	if str := err.Error(); str != "inverter 0: Cumulated Energy Reading: Variable does not exist" {
+		t.Errorf("Unexpected string returned: %s", str)
 	}
 }
 
@@ -195,8 +185,8 @@ func TestCommunicateReadError(t *testing.T) {
 		ttys1.(*mockSerial).PipeWriter.Close()
 	}()
 	_, err := i.Communicate(aurora.GetCumulatedEnergy, aurora.CumulatedMonthly)
-	if err == nil {
-		t.Error("Expected error")
+	if !errors.Is(err, aurora.ErrShortRead) {
+		t.Errorf("Expected %v got %v", aurora.ErrShortRead, err)
 	}
 }
 
@@ -208,11 +198,54 @@ func TestCommunicateVarError(t *testing.T) {
 	// Push a bad CRC in the response
 	go makeCRCError(t, ttys1)
 	err := i.CommunicateVar(&resp, aurora.GetCumulatedEnergy, aurora.CumulatedMonthly)
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
 
+func TestCommunicateContextTimeout(t *testing.T) {
+	ttys0, ttys1 := net.Pipe()
+	defer ttys1.Close()
+	i := &aurora.Inverter{Conn: ttys0}
+
+	// Swallow the request and never respond
+	go io.Copy(ioutil.Discard, ttys1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := i.CommunicateContext(ctx, aurora.GetCumulatedEnergy, aurora.CumulatedMonthly)
+	if !errors.Is(err, aurora.ErrTimeout) {
+		t.Errorf("Expected %v got %v", aurora.ErrTimeout, err)
+	}
+}
+
+func TestCommunicateContextCancel(t *testing.T) {
+	ttys0, ttys1 := net.Pipe()
+	defer ttys1.Close()
+	i := &aurora.Inverter{Conn: ttys0}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Swallow the request and cancel rather than respond
+	go func() {
+		tmp := make([]byte, 10)
+		io.ReadFull(ttys1, tmp)
+		cancel()
+	}()
+
+	_, err := i.CommunicateContext(ctx, aurora.GetCumulatedEnergy, aurora.CumulatedMonthly)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected %v got %v", context.Canceled, err)
+	}
+
+	// Already done, nothing should be transmitted
+	_, err = i.StateContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected %v got %v", context.Canceled, err)
+	}
+}
+
 func TestCommCheck(t *testing.T) {
 	i := mockInverterExpect(t, []byte{0x02, 0x3a, 0x00, 0x20, 0x20, 0x20, 0x20, 0x20, 0xc9, 0x59}, []byte{0x00, 0x06, 0x49, 0x4b, 0x4e, 0x4e})
 	err := i.CommCheck()
@@ -221,7 +254,7 @@ func TestCommCheck(t *testing.T) {
 	}
 
 	err = i.CommCheck()
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
@@ -246,7 +279,7 @@ func TestState(t *testing.T) {
 	}
 
 	_, err = i.State()
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
@@ -265,7 +298,7 @@ func TestLast4Alarms(t *testing.T) {
 	}
 
 	_, err = i.Last4Alarms()
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
@@ -282,7 +315,7 @@ func TestPartNumber(t *testing.T) {
 	}
 
 	_, err = i.PartNumber()
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
@@ -299,7 +332,7 @@ func TestSerialNumber(t *testing.T) {
 	}
 
 	_, err = i.SerialNumber()
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
@@ -323,7 +356,7 @@ func TestVersion(t *testing.T) {
 	}
 
 	_, err = i.Version()
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
@@ -347,7 +380,7 @@ func TestManufactureDate(t *testing.T) {
 	}
 
 	_, _, err = i.ManufactureDate()
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
@@ -366,7 +399,26 @@ func TestFirmwareVersion(t *testing.T) {
 	}
 
 	_, err = i.FirmwareVersion()
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
+		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
+	}
+}
+
+func TestFlags(t *testing.T) {
+	i := mockInverterExpect(t, []byte{0x02, 0x43, 0x00, 0x20, 0x20, 0x20, 0x20, 0x20, 0xbc, 0x09}, []byte{0x00, 0x06, 0x01, 0x80, 0x03, 0x00})
+	flags, err := i.Flags()
+	if err != nil {
+		t.Error(err)
+	}
+
+	expectedFlags := &aurora.Flags{Flag1: 0x01, Flag2: 0x80, Switch1: 0x03}
+
+	if !reflect.DeepEqual(flags, expectedFlags) {
+		t.Errorf("Expected %v got %v", expectedFlags, flags)
+	}
+
+	_, err = i.Flags()
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
@@ -385,7 +437,7 @@ func TestConfiguration(t *testing.T) {
 	}
 
 	_, err = i.Configuration()
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
@@ -397,14 +449,14 @@ func TestDailyEnergy(t *testing.T) {
 		t.Error(err)
 	}
 
-	expectedEnergy := uint32(12345)
+	expectedEnergy := aurora.WattHours(12345)
 
 	if energy != expectedEnergy {
 		t.Errorf("Expected %d got %d", expectedEnergy, energy)
 	}
 
 	_, err = i.DailyEnergy()
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
@@ -416,14 +468,33 @@ func TestWeeklyEnergy(t *testing.T) {
 		t.Error(err)
 	}
 
-	expectedEnergy := uint32(86415)
+	expectedEnergy := aurora.WattHours(86415)
 
 	if energy != expectedEnergy {
 		t.Errorf("Expected %d got %d", expectedEnergy, energy)
 	}
 
 	_, err = i.WeeklyEnergy()
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
+		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
+	}
+}
+
+func TestLast7DaysEnergy(t *testing.T) {
+	i := mockInverterExpect(t, []byte{0x02, 0x4e, 0x02, 0x00, 0x20, 0x20, 0x20, 0x20, 0x34, 0x4f}, []byte{0x00, 0x06, 0x00, 0x01, 0x51, 0x8f})
+	energy, err := i.Last7DaysEnergy()
+	if err != nil {
+		t.Error(err)
+	}
+
+	expectedEnergy := aurora.WattHours(86415)
+
+	if energy != expectedEnergy {
+		t.Errorf("Expected %d got %d", expectedEnergy, energy)
+	}
+
+	_, err = i.Last7DaysEnergy()
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
@@ -435,14 +506,14 @@ func TestMonthlyEnergy(t *testing.T) {
 		t.Error(err)
 	}
 
-	expectedEnergy := uint32(370350)
+	expectedEnergy := aurora.WattHours(370350)
 
 	if energy != expectedEnergy {
 		t.Errorf("Expected %d got %d", expectedEnergy, energy)
 	}
 
 	_, err = i.MonthlyEnergy()
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
@@ -454,14 +525,14 @@ func TestYearlyEnergy(t *testing.T) {
 		t.Error(err)
 	}
 
-	expectedEnergy := uint32(4505925)
+	expectedEnergy := aurora.WattHours(4505925)
 
 	if energy != expectedEnergy {
 		t.Errorf("Expected %d got %d", expectedEnergy, energy)
 	}
 
 	_, err = i.YearlyEnergy()
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
@@ -473,14 +544,14 @@ func TestTotalEnergy(t *testing.T) {
 		t.Error(err)
 	}
 
-	expectedEnergy := uint32(432572318)
+	expectedEnergy := aurora.WattHours(432572318)
 
 	if energy != expectedEnergy {
 		t.Errorf("Expected %d got %d", expectedEnergy, energy)
 	}
 
 	_, err = i.TotalEnergy()
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
@@ -492,14 +563,14 @@ func TestPartialEnergy(t *testing.T) {
 		t.Error(err)
 	}
 
-	expectedEnergy := uint32(5407110)
+	expectedEnergy := aurora.WattHours(5407110)
 
 	if energy != expectedEnergy {
 		t.Errorf("Expected %d got %d", expectedEnergy, energy)
 	}
 
 	_, err = i.PartialEnergy()
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
@@ -511,14 +582,14 @@ func TestFrequency(t *testing.T) {
 		t.Error(err)
 	}
 
-	expectedFrequency := float32(49.9860038757324)
+	expectedFrequency := aurora.Hertz(49.9860038757324)
 
 	if frequency != expectedFrequency {
 		t.Errorf("Expected %f got %f", expectedFrequency, frequency)
 	}
 
 	_, err = i.Frequency()
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
@@ -530,14 +601,14 @@ func TestGridVoltage(t *testing.T) {
 		t.Error(err)
 	}
 
-	expectedGridVoltage := float32(234.878860473633)
+	expectedGridVoltage := aurora.Volts(234.878860473633)
 
 	if gridVoltage != expectedGridVoltage {
 		t.Errorf("Expected %f got %f", expectedGridVoltage, gridVoltage)
 	}
 
 	_, err = i.GridVoltage()
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
@@ -549,14 +620,14 @@ func TestGridCurrent(t *testing.T) {
 		t.Error(err)
 	}
 
-	expectedGridCurrent := float32(0.927210628986359)
+	expectedGridCurrent := aurora.Amps(0.927210628986359)
 
 	if gridCurrent != expectedGridCurrent {
 		t.Errorf("Expected %f got %f", expectedGridCurrent, gridCurrent)
 	}
 
 	_, err = i.GridCurrent()
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
@@ -568,14 +639,14 @@ func TestGridPower(t *testing.T) {
 		t.Error(err)
 	}
 
-	expectedGridPower := float32(73.6911010742188)
+	expectedGridPower := aurora.Watts(73.6911010742188)
 
 	if gridPower != expectedGridPower {
 		t.Errorf("Expected %f got %f", expectedGridPower, gridPower)
 	}
 
 	_, err = i.GridPower()
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
@@ -587,14 +658,14 @@ func TestInput1Voltage(t *testing.T) {
 		t.Error(err)
 	}
 
-	expectedInput1Voltage := float32(64.9114990234375)
+	expectedInput1Voltage := aurora.Volts(64.9114990234375)
 
 	if input1Voltage != expectedInput1Voltage {
 		t.Errorf("Expected %f got %f", expectedInput1Voltage, input1Voltage)
 	}
 
 	_, err = i.Input1Voltage()
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
@@ -606,14 +677,14 @@ func TestInput1Current(t *testing.T) {
 		t.Error(err)
 	}
 
-	expectedInput1Current := float32(0.0187656991183758)
+	expectedInput1Current := aurora.Amps(0.0187656991183758)
 
 	if input1Current != expectedInput1Current {
 		t.Errorf("Expected %f got %f", expectedInput1Current, input1Current)
 	}
 
 	_, err = i.Input1Current()
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
@@ -625,14 +696,14 @@ func TestInput2Voltage(t *testing.T) {
 		t.Error(err)
 	}
 
-	expectedInput2Voltage := float32(275.287811279297)
+	expectedInput2Voltage := aurora.Volts(275.287811279297)
 
 	if input2Voltage != expectedInput2Voltage {
 		t.Errorf("Expected %f got %f", expectedInput2Voltage, input2Voltage)
 	}
 
 	_, err = i.Input2Voltage()
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
@@ -644,14 +715,14 @@ func TestInput2Current(t *testing.T) {
 		t.Error(err)
 	}
 
-	expectedInput2Current := float32(0.378975600004196)
+	expectedInput2Current := aurora.Amps(0.378975600004196)
 
 	if input2Current != expectedInput2Current {
 		t.Errorf("Expected %f got %f", expectedInput2Current, input2Current)
 	}
 
 	_, err = i.Input2Current()
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
@@ -663,14 +734,14 @@ func TestInverterTemperature(t *testing.T) {
 		t.Error(err)
 	}
 
-	expectedInverterTemperature := float32(63.015495300293)
+	expectedInverterTemperature := aurora.Celsius(63.015495300293)
 
 	if inverterTemperature != expectedInverterTemperature {
 		t.Errorf("Expected %f got %f", expectedInverterTemperature, inverterTemperature)
 	}
 
 	_, err = i.InverterTemperature()
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
@@ -682,14 +753,14 @@ func TestBoosterTemperature(t *testing.T) {
 		t.Error(err)
 	}
 
-	expectedBoosterTemperature := float32(56.1139945983887)
+	expectedBoosterTemperature := aurora.Celsius(56.1139945983887)
 
 	if boosterTemperature != expectedBoosterTemperature {
 		t.Errorf("Expected %f got %f", expectedBoosterTemperature, boosterTemperature)
 	}
 
 	_, err = i.BoosterTemperature()
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
@@ -701,14 +772,14 @@ func TestJoules(t *testing.T) {
 		t.Error(err)
 	}
 
-	expectedJoules := uint16(82)
+	expectedJoules := aurora.Joules(82)
 
 	if joules != expectedJoules {
 		t.Errorf("Expected %d got %d", expectedJoules, joules)
 	}
 
 	_, err = i.Joules()
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
@@ -726,7 +797,7 @@ func TestSetTime(t *testing.T) {
 	}
 
 	err = i.SetTime(expectedTime)
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
@@ -748,7 +819,7 @@ func TestGetTime(t *testing.T) {
 	}
 
 	_, err = i.GetTime()
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
@@ -767,7 +838,7 @@ func TestTotalRunTime(t *testing.T) {
 	}
 
 	_, err = i.TotalRunTime()
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
@@ -786,7 +857,7 @@ func TestPartialRunTime(t *testing.T) {
 	}
 
 	_, err = i.PartialRunTime()
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
@@ -805,7 +876,7 @@ func TestGridRunTime(t *testing.T) {
 	}
 
 	_, err = i.GridRunTime()
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
@@ -818,7 +889,36 @@ func TestResetRunTime(t *testing.T) {
 	}
 
 	err = i.ResetRunTime()
-	if err != aurora.ErrCRCFailure {
+	if !errors.Is(err, aurora.ErrCRCFailure) {
 		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
 	}
 }
+
+func TestRaw(t *testing.T) {
+	i := simulatedInverter(t, aurorasim.NewInverter(2))
+
+	response, err := i.Raw(aurora.GetDSP, aurora.DSParameter(200))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state := response.State(); state != aurora.TSVariableDoesNotExist {
+		t.Errorf("Expected %v got %v", aurora.TSVariableDoesNotExist, state)
+	}
+
+	// Unknown commands are sent regardless
+	response, err = i.Raw(aurora.Command(99), aurora.Byte(1), aurora.Byte(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state := response.State(); state != aurora.TSCommandNotImplemented {
+		t.Errorf("Expected %v got %v", aurora.TSCommandNotImplemented, state)
+	}
+
+	response, err = i.Raw(aurora.GetSerialNumber)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if serial := string(response.Payload[:]); serial != "123456" {
+		t.Errorf("Expected %s got %s", "123456", serial)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+/*
+Package aurorasim simulates the inverter side of the Aurora protocol so that
+software talking to inverters can be tested without any hardware.
+
+A Simulator answers requests read from any io.ReadWriter on behalf of one or
+more virtual inverters:
+
+	ttys0, ttys1 := net.Pipe()
+	sim := aurorasim.New(aurorasim.NewInverter(2))
+	go sim.Serve(ttys1)
+
+	inverter := aurora.NewBus(ttys0).Inverter(2)
+	version, err := inverter.Version()
+*/
+package aurorasim
+
+import (
+	"io"
+	"sync"
+
+	"github.com/freman/go-aurora"
+)
+
+// Simulator answers requests on behalf of a number of virtual inverters
+type Simulator struct {
+	// Echo is whether to echo every request back before answering it, as a
+	// half-duplex RS485 adapter would
+	Echo bool
+
+	mu        sync.RWMutex
+	inverters map[byte]*Inverter
+}
+
+// New returns a simulator for the given inverters
+func New(inverters ...*Inverter) *Simulator {
+	s := &Simulator{}
+	for _, inverter := range inverters {
+		s.Add(inverter)
+	}
+	return s
+}
+
+// Add connects an inverter to the simulated bus, replacing any other at the
+// same address
+func (s *Simulator) Add(inverter *Inverter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.inverters == nil {
+		s.inverters = map[byte]*Inverter{}
+	}
+	s.inverters[inverter.Address] = inverter
+}
+
+// Remove disconnects the inverter at the given address from the simulated bus
+func (s *Simulator) Remove(address byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.inverters, address)
+}
+
+// Inverter returns the inverter at the given address, or nil if there isn't one
+func (s *Simulator) Inverter(address byte) *Inverter {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.inverters[address]
+}
+
+// Serve reads requests from conn and writes the responses until reading fails.
+// Like a real bus, requests with a bad CRC or for an address nobody answers to
+// go unanswered, and stray bytes are skipped until a valid request lines up.
+// Serve returns nil once conn reaches EOF.
+func (s *Simulator) Serve(conn io.ReadWriter) error {
+	request := make([]byte, aurora.RequestFrameSize)
+	if _, err := io.ReadFull(conn, request); err != nil {
+		return eof(err)
+	}
+
+	for {
+		var frame aurora.RequestFrame
+		if frame.UnmarshalBinary(request) != nil {
+			// Slide along a byte in search of a frame
+			copy(request, request[1:])
+			if _, err := io.ReadFull(conn, request[aurora.RequestFrameSize-1:]); err != nil {
+				return eof(err)
+			}
+			continue
+		}
+
+		if response := s.Handle(request); response != nil {
+			if s.Echo {
+				if _, err := conn.Write(request); err != nil {
+					return err
+				}
+			}
+			if _, err := conn.Write(response); err != nil {
+				return err
+			}
+		}
+
+		if _, err := io.ReadFull(conn, request); err != nil {
+			return eof(err)
+		}
+	}
+}
+
+// Handle returns the response frame to a single request frame, or nil if it
+// would go unanswered
+func (s *Simulator) Handle(request []byte) []byte {
+	var frame aurora.RequestFrame
+	if frame.UnmarshalBinary(request) != nil {
+		return nil
+	}
+
+	inverter := s.Inverter(frame.Address)
+	if inverter == nil {
+		return nil
+	}
+
+	response := &aurora.ResponseFrame{Payload: inverter.respond(frame.Command, frame.Args[:])}
+	data, _ := response.MarshalBinary()
+	return data
+}
+
+func eof(err error) error {
+	if err == io.EOF {
+		return nil
+	}
+	return err
+}
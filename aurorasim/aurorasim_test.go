@@ -0,0 +1,224 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package aurorasim_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/freman/go-aurora"
+	"github.com/freman/go-aurora/aurorasim"
+)
+
+// serve connects a bus to a simulator for the given inverters
+func serve(t *testing.T, inverters ...*aurorasim.Inverter) (*aurora.Bus, *aurorasim.Simulator) {
+	ttys0, ttys1 := net.Pipe()
+	sim := aurorasim.New(inverters...)
+	go sim.Serve(ttys1)
+	t.Cleanup(func() { ttys0.Close() })
+	return aurora.NewBus(ttys0), sim
+}
+
+func TestSimulatorIdentity(t *testing.T) {
+	bus, _ := serve(t, aurorasim.NewInverter(2))
+	i := bus.Inverter(2)
+
+	version, err := i.Version()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &aurora.Version{
+		Model:       aurora.Product3_6kWOutdoor,
+		Regulation:  aurora.ProductSpecAS4777,
+		Transformer: aurora.InverterTransformerless,
+		Type:        aurora.InputPhotovoltaic,
+	}
+	if !reflect.DeepEqual(expected, version) {
+		t.Errorf("Expected %s got %s", expected, version)
+	}
+
+	if serial, err := i.SerialNumber(); err != nil || serial != "123456" {
+		t.Errorf("Expected %s got %s (%v)", "123456", serial, err)
+	}
+	if part, err := i.PartNumber(); err != nil || part != "-3G79-" {
+		t.Errorf("Expected %s got %s (%v)", "-3G79-", part, err)
+	}
+	if firmware, err := i.FirmwareVersion(); err != nil || firmware != "C.0.1.3" {
+		t.Errorf("Expected %s got %s (%v)", "C.0.1.3", firmware, err)
+	}
+	if year, week, err := i.ManufactureDate(); err != nil || year != "16" || week != "25" {
+		t.Errorf("Expected %s/%s got %s/%s (%v)", "16", "25", year, week, err)
+	}
+}
+
+func TestSimulatorReadings(t *testing.T) {
+	inverter := aurorasim.NewInverter(2)
+	bus, _ := serve(t, inverter)
+	i := bus.Inverter(2)
+
+	inverter.SetDSP(aurora.DSPGridPower, 1234.5)
+	inverter.SetEnergy(aurora.CumulatedDaily, 12345)
+	inverter.AddEnergy(5)
+	inverter.SetCounter(aurora.CounterTotal, 3600)
+
+	if power, err := i.GridPower(); err != nil || power != 1234.5 {
+		t.Errorf("Expected %f got %f (%v)", 1234.5, power, err)
+	}
+	if energy, err := i.DailyEnergy(); err != nil || energy != 12350 {
+		t.Errorf("Expected %d got %d (%v)", 12350, energy, err)
+	}
+	if energy, err := i.TotalEnergy(); err != nil || energy != 5 {
+		t.Errorf("Expected %d got %d (%v)", 5, energy, err)
+	}
+	if energy, err := i.Last7DaysEnergy(); err != nil || energy != 5 {
+		t.Errorf("Expected %d got %d (%v)", 5, energy, err)
+	}
+	if runTime, err := i.TotalRunTime(); err != nil || runTime != time.Hour {
+		t.Errorf("Expected %v got %v (%v)", time.Hour, runTime, err)
+	}
+
+	_, err := i.GetDSPData(aurora.DSPFan1Speed)
+	if !errors.Is(err, aurora.TSVariableDoesNotExist) {
+		t.Errorf("Expected %v got %v", aurora.TSVariableDoesNotExist, err)
+	}
+}
+
+func TestSimulatorFlags(t *testing.T) {
+	inverter := aurorasim.NewInverter(2)
+	inverter.Flags = aurora.Flags{Flag1: 0x01, Switch2: 0x02}
+	bus, _ := serve(t, inverter)
+
+	flags, err := bus.Inverter(2).Flags()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *flags != inverter.Flags {
+		t.Errorf("Expected %v got %v", &inverter.Flags, flags)
+	}
+}
+
+func TestSimulatorStateAndAlarms(t *testing.T) {
+	inverter := aurorasim.NewInverter(2)
+	bus, _ := serve(t, inverter)
+	i := bus.Inverter(2)
+
+	inverter.Raise(aurora.AlarmGridFail)
+	inverter.Raise(aurora.AlarmGroundFault18)
+
+	state, err := i.State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Global != aurora.GSRun || state.Alarm != aurora.AlarmGroundFault18 {
+		t.Errorf("Unexpected state %s", state)
+	}
+
+	alarms, err := i.Last4Alarms()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []aurora.AlarmState{aurora.AlarmNone, aurora.AlarmNone, aurora.AlarmGridFail, aurora.AlarmGroundFault18}
+	if !reflect.DeepEqual(expected, alarms) {
+		t.Errorf("Expected %v got %v", expected, alarms)
+	}
+}
+
+func TestSimulatorClock(t *testing.T) {
+	now := time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC)
+	inverter := aurorasim.NewInverter(2)
+	inverter.Now = func() time.Time { return now }
+	bus, _ := serve(t, inverter)
+	i := bus.Inverter(2)
+
+	set := time.Date(2016, 1, 1, 8, 30, 0, 0, time.UTC)
+	if err := i.SetTime(set); err != nil {
+		t.Fatal(err)
+	}
+
+	now = now.Add(time.Minute)
+	clock, err := i.GetTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := set.Add(time.Minute); !clock.Equal(expected) {
+		t.Errorf("Expected %v got %v", expected, clock)
+	}
+}
+
+func TestSimulatorFail(t *testing.T) {
+	inverter := aurorasim.NewInverter(2)
+	bus, _ := serve(t, inverter)
+	bus.Retry = &aurora.RetryPolicy{Attempts: 3}
+	i := bus.Inverter(2)
+
+	// Transient failures are retried away
+	inverter.Fail(aurora.GetDSP, aurora.TSVariableNotAvailable, 2)
+	if _, err := i.GridVoltage(); err != nil {
+		t.Error(err)
+	}
+	if stats := bus.Stats(); stats.Retries != 2 {
+		t.Errorf("Expected %d retries got %d", 2, stats.Retries)
+	}
+
+	inverter.Fail(aurora.GetState, aurora.TSEEpromNotAccessible, 0)
+	for n := 0; n < 2; n++ {
+		if _, err := i.State(); !errors.Is(err, aurora.TSEEpromNotAccessible) {
+			t.Errorf("Expected %v got %v", aurora.TSEEpromNotAccessible, err)
+		}
+	}
+
+	inverter.Recover(aurora.GetState)
+	if _, err := i.State(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSimulatorScan(t *testing.T) {
+	other := aurorasim.NewInverter(7)
+	other.SerialNumber = "777777"
+	bus, _ := serve(t, aurorasim.NewInverter(3), other)
+	bus.ScanTimeout = 20 * time.Millisecond
+
+	units, err := bus.Scan(context.Background(), 1, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(units) != 2 || units[0].Address != 3 || units[1].Address != 7 {
+		t.Fatalf("Expected units at 3 and 7 got %v", units)
+	}
+	if units[1].SerialNumber != "777777" {
+		t.Errorf("Expected %s got %s", "777777", units[1].SerialNumber)
+	}
+}
+
+func TestSimulatorEcho(t *testing.T) {
+	bus, sim := serve(t, aurorasim.NewInverter(2))
+	sim.Echo = true
+
+	if err := bus.Inverter(2).CommCheck(); err != nil {
+		t.Fatal(err)
+	}
+	if echoes := bus.Stats().Echoes; echoes != 1 {
+		t.Errorf("Expected %d echoes got %d", 1, echoes)
+	}
+}
+
+func TestSimulatorHandle(t *testing.T) {
+	sim := aurorasim.New(aurorasim.NewInverter(2))
+
+	// GetVersion for address 2 with a broken CRC, then a valid one for address 3
+	for _, request := range [][]byte{
+		{2, 58, 0, 32, 32, 32, 32, 32, 0, 0},
+		{3, 58, 0, 32, 32, 32, 32, 32, 0x76, 0xd8},
+	} {
+		if response := sim.Handle(request); response != nil {
+			t.Errorf("Expected no response to % X got % X", request, response)
+		}
+	}
+}
@@ -0,0 +1,326 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package aurorasim
+
+import (
+	"encoding/binary"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/freman/go-aurora"
+)
+
+// Inverter is a virtual inverter. Set the fields before serving it and use the
+// methods to change it while it is being served.
+type Inverter struct {
+	Address byte
+
+	Version         aurora.Version
+	PartNumber      string // Up to 6 characters
+	SerialNumber    string // Up to 6 characters
+	Firmware        string // 4 characters, optionally separated by dots as in C.0.1.3
+	ManufactureWeek string // 2 digits
+	ManufactureYear string // 2 digits
+	Configuration   aurora.ConfigurationState
+	Flags           aurora.Flags
+
+	State    aurora.State
+	DSP      map[aurora.DSParameter]float32
+	Energy   map[aurora.CumulationPeriod]uint32 // Watt hours
+	Counters map[aurora.Counter]uint32          // Seconds
+	Joules   uint16                             // Energy exported in the last 10 seconds
+
+	// Alarms is the alarm history, oldest first, as returned by GetLast4Alarms
+	Alarms [4]aurora.AlarmState
+
+	// Now is the time according to the simulator, the inverter clock runs at
+	// an offset from it. Defaults to time.Now.
+	Now func() time.Time
+
+	mu       sync.Mutex
+	clock    time.Duration
+	failures map[aurora.Command]*failure
+}
+
+type failure struct {
+	state aurora.TransmissionState
+	count int
+}
+
+// NewInverter returns a healthy, running, single phase photovoltaic inverter at
+// the given address
+func NewInverter(address byte) *Inverter {
+	return &Inverter{
+		Address: address,
+		Version: aurora.Version{
+			Model:       aurora.Product3_6kWOutdoor,
+			Regulation:  aurora.ProductSpecAS4777,
+			Transformer: aurora.InverterTransformerless,
+			Type:        aurora.InputPhotovoltaic,
+		},
+		PartNumber:      "-3G79-",
+		SerialNumber:    "123456",
+		Firmware:        "C.0.1.3",
+		ManufactureWeek: "25",
+		ManufactureYear: "16",
+		Configuration:   aurora.ConfigBoth,
+		State: aurora.State{
+			Global:   aurora.GSRun,
+			Inverter: aurora.ISRun,
+			Channel1: aurora.DCDCMPPT,
+			Channel2: aurora.DCDCMPPT,
+			Alarm:    aurora.AlarmNone,
+		},
+		DSP: map[aurora.DSParameter]float32{
+			aurora.DSPGridVoltage:         240,
+			aurora.DSPGridCurrent:         4,
+			aurora.DSPGridPower:           960,
+			aurora.DSPFrequency:           50,
+			aurora.DSPInput1Voltage:       320,
+			aurora.DSPInput1Current:       1.6,
+			aurora.DSPInput2Voltage:       320,
+			aurora.DSPInput2Current:       1.6,
+			aurora.DSPInverterTemperature: 35,
+			aurora.DSPBoosterTemperature:  32,
+			aurora.DSPIsolationResistance: 20,
+		},
+		Energy: map[aurora.CumulationPeriod]uint32{
+			aurora.CumulatedDaily:     0,
+			aurora.CumulatedWeekly:    0,
+			aurora.CumulatedLast7Days: 0,
+			aurora.CumulatedMonthly:   0,
+			aurora.CumulatedYearly:    0,
+			aurora.CumulatedTotal:     0,
+			aurora.CumulatedPartial:   0,
+		},
+		Counters: map[aurora.Counter]uint32{
+			aurora.CounterTotal:   0,
+			aurora.CounterPartial: 0,
+			aurora.CounterGrid:    0,
+		},
+	}
+}
+
+// SetState changes the state reported by the inverter
+func (i *Inverter) SetState(state aurora.State) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.State = state
+}
+
+// SetDSP changes the value of a DSP parameter, parameters without a value are
+// reported as not existing
+func (i *Inverter) SetDSP(parameter aurora.DSParameter, value float32) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.DSP == nil {
+		i.DSP = map[aurora.DSParameter]float32{}
+	}
+	i.DSP[parameter] = value
+}
+
+// SetEnergy changes the cumulated energy for a period
+func (i *Inverter) SetEnergy(period aurora.CumulationPeriod, wh uint32) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.Energy == nil {
+		i.Energy = map[aurora.CumulationPeriod]uint32{}
+	}
+	i.Energy[period] = wh
+}
+
+// AddEnergy adds to the cumulated energy of every period
+func (i *Inverter) AddEnergy(wh uint32) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for period := range i.Energy {
+		i.Energy[period] += wh
+	}
+}
+
+// SetCounter changes the value of a counter
+func (i *Inverter) SetCounter(counter aurora.Counter, seconds uint32) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.Counters == nil {
+		i.Counters = map[aurora.Counter]uint32{}
+	}
+	i.Counters[counter] = seconds
+}
+
+// Raise raises an alarm, making it the current alarm and adding it to the history
+func (i *Inverter) Raise(alarm aurora.AlarmState) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.State.Alarm = alarm
+	copy(i.Alarms[:], i.Alarms[1:])
+	i.Alarms[len(i.Alarms)-1] = alarm
+}
+
+// Clear clears the current alarm, leaving the history intact
+func (i *Inverter) Clear() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.State.Alarm = aurora.AlarmNone
+}
+
+// Fail makes the inverter answer the next count requests for the given command
+// with the given transmission state, or every request when count is zero
+func (i *Inverter) Fail(command aurora.Command, state aurora.TransmissionState, count int) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.failures == nil {
+		i.failures = map[aurora.Command]*failure{}
+	}
+	i.failures[command] = &failure{state: state, count: count}
+}
+
+// Recover undoes Fail for the given command
+func (i *Inverter) Recover(command aurora.Command) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.failures, command)
+}
+
+// Clock returns the time according to the inverter
+func (i *Inverter) Clock() time.Time {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.now()
+}
+
+// SetClock sets the time according to the inverter
+func (i *Inverter) SetClock(t time.Time) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.setClock(t)
+}
+
+func (i *Inverter) now() time.Time {
+	now := time.Now
+	if i.Now != nil {
+		now = i.Now
+	}
+	return now().Add(i.clock)
+}
+
+func (i *Inverter) setClock(t time.Time) {
+	i.clock = 0
+	i.clock = t.Sub(i.now())
+}
+
+// failed returns any injected transmission state for the command
+func (i *Inverter) failed(command aurora.Command) aurora.TransmissionState {
+	f, ok := i.failures[command]
+	if !ok {
+		return aurora.TSOk
+	}
+	if f.count > 0 {
+		if f.count--; f.count == 0 {
+			delete(i.failures, command)
+		}
+	}
+	return f.state
+}
+
+// respond returns the response payload for a command and its arguments
+func (i *Inverter) respond(command aurora.Command, args []byte) (payload [6]byte) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	payload[1] = byte(i.State.Global)
+	if state := i.failed(command); state != aurora.TSOk {
+		payload[0] = byte(state)
+		return
+	}
+
+	// Part and serial numbers take up the whole payload, leaving no room for
+	// a transmission state
+	if command == aurora.GetPartNumber || command == aurora.GetSerialNumber {
+		value := i.PartNumber
+		if command == aurora.GetSerialNumber {
+			value = i.SerialNumber
+		}
+		copy(payload[:], pad(value, 6))
+		return
+	}
+
+	data := payload[2:]
+	switch command {
+	case aurora.GetState:
+		payload[2] = byte(i.State.Inverter)
+		payload[3] = byte(i.State.Channel1)
+		payload[4] = byte(i.State.Channel2)
+		payload[5] = byte(i.State.Alarm)
+	case aurora.GetVersion:
+		payload[2] = byte(i.Version.Model)
+		payload[3] = byte(i.Version.Regulation)
+		payload[4] = byte(i.Version.Transformer)
+		payload[5] = byte(i.Version.Type)
+	case aurora.GetDSP:
+		value, ok := i.DSP[aurora.DSParameter(args[0])]
+		if !ok {
+			payload[0] = byte(aurora.TSVariableDoesNotExist)
+			return
+		}
+		binary.BigEndian.PutUint32(data, math.Float32bits(value))
+	case aurora.GetManufacturingDate:
+		copy(data, pad(i.ManufactureWeek, 2)+pad(i.ManufactureYear, 2))
+	case aurora.GetTime:
+		binary.BigEndian.PutUint32(data, uint32(i.now().Unix()-aurora.InverterEpochOffset))
+	case aurora.SetTime:
+		i.setClock(time.Unix(int64(binary.BigEndian.Uint32(args))+aurora.InverterEpochOffset, 0))
+	case aurora.GetFirmwareVersion:
+		copy(data, pad(strings.Replace(i.Firmware, ".", "", -1), 4))
+	case aurora.GetLast10SecEnergy:
+		binary.BigEndian.PutUint16(data, i.Joules)
+	case aurora.GetConfiguration:
+		payload[2] = byte(i.Configuration)
+	case aurora.GetFlags:
+		payload[2] = i.Flags.Flag1
+		payload[3] = i.Flags.Flag2
+		payload[4] = i.Flags.Switch1
+		payload[5] = i.Flags.Switch2
+	case aurora.GetCumulatedEnergy:
+		value, ok := i.Energy[aurora.CumulationPeriod(args[0])]
+		if !ok {
+			payload[0] = byte(aurora.TSVariableDoesNotExist)
+			return
+		}
+		binary.BigEndian.PutUint32(data, value)
+	case aurora.GetCounters:
+		counter := aurora.Counter(args[0])
+		if counter == aurora.CounterReset {
+			if _, ok := i.Counters[aurora.CounterPartial]; ok {
+				i.Counters[aurora.CounterPartial] = 0
+			}
+			return
+		}
+		value, ok := i.Counters[counter]
+		if !ok {
+			payload[0] = byte(aurora.TSVariableDoesNotExist)
+			return
+		}
+		binary.BigEndian.PutUint32(data, value)
+	case aurora.GetLast4Alarms:
+		for n, alarm := range i.Alarms {
+			data[n] = byte(alarm)
+		}
+	default:
+		payload[0] = byte(aurora.TSCommandNotImplemented)
+	}
+
+	return
+}
+
+// pad truncates or space pads a string to the given length
+func pad(s string, length int) string {
+	if len(s) > length {
+		return s[:length]
+	}
+	return s + strings.Repeat(" ", length-len(s))
+}
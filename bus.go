@@ -0,0 +1,293 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package aurora
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// Bus represents an RS485 bus shared by one or more inverters. It owns the
+// connection and serialises whole request/response transactions so frames for
+// different addresses never interleave on the wire.
+type Bus struct {
+	Conn io.ReadWriter
+
+	// Retry is the policy for retrying failed exchanges used by any handle that
+	// doesn't have its own, when nil a single attempt is made
+	Retry *RetryPolicy
+
+	// ResyncBytes is the number of bytes that may be skipped while sliding over
+	// the input looking for a response with a valid CRC, when zero the first 8
+	// bytes received are taken as the response. Resynchronising needs a Conn
+	// that supports read deadlines and an IdleTimeout, without them it is off.
+	ResyncBytes int
+
+	// Echo is whether the adapter echoes transmitted bytes back, as many cheap
+	// half-duplex RS485 adapters do. The default, EchoAuto, consumes an echo of
+	// the request whenever one is received.
+	Echo EchoMode
+
+	// ScanTimeout is how long Scan waits for each address to respond, when zero
+	// DefaultScanTimeout is used
+	ScanTimeout time.Duration
+
+	// IdleTimeout is how long to wait for more input when draining stale input
+	// before transmitting, and between bytes while resynchronising. It needs a
+	// Conn that supports read deadlines, when zero only a Conn that implements
+	// Flush is drained and responses are never resynchronised.
+	IdleTimeout time.Duration
+
+	once sync.Once
+	sem  chan struct{}
+
+	statsMu sync.Mutex
+	stats   Stats
+}
+
+// Stats holds counters for the exchanges made over a bus
+type Stats struct {
+	Requests uint64 // Calls to Communicate and friends
+	Attempts uint64 // Exchanges attempted, including retries
+	Retries  uint64 // Exchanges attempted again after a failure
+	Failures uint64 // Requests that failed after exhausting any retries
+
+	DiscardedBytes uint64 // Stale or stray bytes drained or skipped over
+	Echoes         uint64 // Echoed requests consumed
+}
+
+// EchoMode is whether the adapter connecting to the bus echoes transmitted bytes
+type EchoMode byte
+
+// Echo modes
+const (
+	EchoAuto EchoMode = iota // Consume an echo of the request if one is received
+	EchoOff                  // The adapter doesn't echo, take what is received as the response
+	EchoOn                   // The adapter always echoes, fail if the echo isn't received
+)
+
+// Defaults used by NewBus
+const (
+	DefaultResyncBytes = 32
+	DefaultIdleTimeout = 5 * time.Millisecond
+)
+
+// maxDrainBytes caps how much is drained, should another master be chattering
+const maxDrainBytes = 1024
+
+// flusher is implemented by serial ports that can discard their input buffer
+type flusher interface {
+	Flush() error
+}
+
+// NewBus returns a Bus communicating over the given connection with sensible
+// defaults, retrying as per DefaultRetryPolicy and recovering from garbage on
+// the line by draining and resynchronising
+func NewBus(conn io.ReadWriter) *Bus {
+	return &Bus{
+		Conn:        conn,
+		Retry:       DefaultRetryPolicy,
+		ResyncBytes: DefaultResyncBytes,
+		IdleTimeout: DefaultIdleTimeout,
+	}
+}
+
+// Inverter returns a handle for the inverter at the given address on the bus.
+// Handles are safe for use from many goroutines at once, and any number of them
+// may exist for the same address.
+func (b *Bus) Inverter(address byte) *Inverter {
+	return &Inverter{
+		Conn:    b.Conn,
+		Address: address,
+		bus:     b,
+	}
+}
+
+// Stats returns a snapshot of the counters for the bus
+func (b *Bus) Stats() Stats {
+	b.statsMu.Lock()
+	defer b.statsMu.Unlock()
+	return b.stats
+}
+
+func (b *Bus) count(f func(s *Stats)) {
+	b.statsMu.Lock()
+	f(&b.stats)
+	b.statsMu.Unlock()
+}
+
+// acquire waits for exclusive use of the bus, giving up once the context is done
+func (b *Bus) acquire(ctx context.Context) error {
+	b.once.Do(func() {
+		b.sem = make(chan struct{}, 1)
+	})
+
+	select {
+	case b.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release gives up exclusive use of the bus
+func (b *Bus) release() {
+	<-b.sem
+}
+
+// aLongTimeAgo is a non-zero time in the past, used to immediately unblock any
+// pending reads or writes on a connection that supports deadlines
+var aLongTimeAgo = time.Unix(1, 0)
+
+type readDeadliner interface {
+	SetReadDeadline(t time.Time) error
+}
+
+type writeDeadliner interface {
+	SetWriteDeadline(t time.Time) error
+}
+
+// drain discards any stale input, such as a late reply to a request that timed
+// out, so it isn't mistaken for the response to the next one
+func (b *Bus) drain(ctx context.Context) {
+	if f, ok := b.Conn.(flusher); ok {
+		f.Flush()
+		return
+	}
+
+	rd, ok := b.Conn.(readDeadliner)
+	if !ok || b.IdleTimeout <= 0 {
+		return
+	}
+
+	buf := make([]byte, 64)
+	drained := 0
+	for drained < maxDrainBytes && ctx.Err() == nil {
+		rd.SetReadDeadline(time.Now().Add(b.IdleTimeout))
+		n, err := b.Conn.Read(buf)
+		drained += n
+		if err != nil {
+			break
+		}
+	}
+
+	if drained > 0 {
+		b.count(func(s *Stats) { s.DiscardedBytes += uint64(drained) })
+	}
+}
+
+// readResponse reads the response to the given request from the connection,
+// first consuming the echo of the request as per the Echo mode
+func (b *Bus) readResponse(ctx context.Context, request []byte) ([]byte, error) {
+	frame := make([]byte, 8)
+	if _, err := io.ReadFull(b.Conn, frame); err != nil {
+		return nil, err
+	}
+
+	if b.Echo != EchoOff {
+		if bytes.Equal(frame, request[:8]) {
+			tail := make([]byte, len(request)-8)
+			if _, err := io.ReadFull(b.Conn, tail); err != nil {
+				return nil, err
+			}
+			if !bytes.Equal(tail, request[8:]) {
+				return nil, ErrEchoMismatch
+			}
+			b.count(func(s *Stats) { s.Echoes++ })
+
+			if _, err := io.ReadFull(b.Conn, frame); err != nil {
+				return nil, err
+			}
+		} else if b.Echo == EchoOn {
+			return nil, ErrEchoMismatch
+		}
+	}
+
+	return b.resync(ctx, frame), nil
+}
+
+// resync checks the CRC of the frame, if it doesn't match it slides along the
+// input one byte at a time, up to ResyncBytes, looking for a frame that does. It
+// only waits IdleTimeout for each further byte, so a frame that was merely
+// corrupted en route is returned as is once the line goes quiet. Without a Conn
+// that supports read deadlines there'd be no telling when to stop waiting, so
+// the frame is returned as is.
+func (b *Bus) resync(ctx context.Context, frame []byte) []byte {
+	rd, ok := b.Conn.(readDeadliner)
+	if !ok || b.IdleTimeout <= 0 {
+		return frame
+	}
+	deadline, hasDeadline := ctx.Deadline()
+
+	skipped := 0
+	next := make([]byte, 1)
+	for ; skipped < b.ResyncBytes && !validFrame(frame) && ctx.Err() == nil; skipped++ {
+		idle := time.Now().Add(b.IdleTimeout)
+		if hasDeadline && deadline.Before(idle) {
+			idle = deadline
+		}
+		rd.SetReadDeadline(idle)
+		if _, err := io.ReadFull(b.Conn, next); err != nil {
+			break
+		}
+		copy(frame, frame[1:])
+		frame[7] = next[0]
+	}
+
+	if skipped > 0 {
+		b.count(func(s *Stats) { s.DiscardedBytes += uint64(skipped) })
+	}
+
+	return frame
+}
+
+// validFrame returns true if the CRC of the response frame matches its payload
+func validFrame(frame []byte) bool {
+	return CRC(frame[:6]) == uint16(frame[6])|uint16(frame[7])<<8
+}
+
+// watch applies the deadline of the context to the connection and arranges for
+// any blocking read or write to be interrupted should the context be cancelled.
+// The returned func must be called once the exchange is complete, it waits for
+// the watcher to exit so no goroutine outlives the call.
+func (b *Bus) watch(ctx context.Context) func() {
+	deadline, _ := ctx.Deadline()
+	rd, canRead := b.Conn.(readDeadliner)
+	wd, canWrite := b.Conn.(writeDeadliner)
+	if canRead {
+		rd.SetReadDeadline(deadline)
+	}
+	if canWrite {
+		wd.SetWriteDeadline(deadline)
+	}
+
+	if ctx.Done() == nil || !(canRead || canWrite) {
+		return func() {}
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		select {
+		case <-ctx.Done():
+			if canRead {
+				rd.SetReadDeadline(aLongTimeAgo)
+			}
+			if canWrite {
+				wd.SetWriteDeadline(aLongTimeAgo)
+			}
+		case <-stop:
+		}
+	}()
+
+	return func() {
+		close(stop)
+		<-done
+	}
+}
@@ -0,0 +1,295 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package aurora_test
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/freman/go-aurora"
+)
+
+// mockBus answers every request with the address and command it was sent
+func mockBus(conn io.ReadWriter) {
+	for {
+		tmp := make([]byte, 10)
+		if _, err := io.ReadFull(conn, tmp); err != nil {
+			return
+		}
+
+		// Write the CRC separately to tempt any interleaving
+		res := []byte{0, 6, tmp[0], tmp[1], 0, 0}
+		binary.Write(conn, binary.LittleEndian, res)
+		binary.Write(conn, binary.LittleEndian, aurora.CRC(res))
+	}
+}
+
+func TestBusConcurrentHandles(t *testing.T) {
+	ttys0, ttys1 := mockSerialPair()
+	bus := aurora.NewBus(ttys0)
+	go mockBus(ttys1)
+
+	var wg sync.WaitGroup
+	for address := byte(1); address <= 8; address++ {
+		wg.Add(1)
+		go func(inverter *aurora.Inverter) {
+			defer wg.Done()
+			for n := 0; n < 20; n++ {
+				result, err := inverter.Communicate(aurora.GetDSP, aurora.DSPGridPower)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if result[0] != inverter.Address || result[1] != byte(aurora.GetDSP) {
+					t.Errorf("Expected response for %d, got % X", inverter.Address, result)
+					return
+				}
+			}
+		}(bus.Inverter(address))
+	}
+	wg.Wait()
+}
+
+func TestBusAcquireCancel(t *testing.T) {
+	ttys0, ttys1 := mockSerialPair()
+	bus := aurora.NewBus(ttys0)
+	busy := bus.Inverter(2)
+	waiting := bus.Inverter(3)
+
+	// Hold the bus by never responding to the first request
+	started := make(chan struct{})
+	go func() {
+		tmp := make([]byte, 10)
+		io.ReadFull(ttys1, tmp)
+		close(started)
+	}()
+	go busy.Communicate(aurora.GetVersion)
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := waiting.VersionContext(ctx)
+	if !errors.Is(err, aurora.ErrTimeout) {
+		t.Errorf("Expected %v got %v", aurora.ErrTimeout, err)
+	}
+}
+
+func TestBusResync(t *testing.T) {
+	// Needs deadlines to resynchronise
+	ttys0, ttys1 := net.Pipe()
+	defer ttys1.Close()
+	bus := aurora.NewBus(ttys0)
+	i := bus.Inverter(2)
+
+	// A late reply to some earlier request precedes the real one
+	go func() {
+		tmp := make([]byte, 10)
+		io.ReadFull(ttys1, tmp)
+		res := []byte{0, 6, 0, 0, 0x30, 0x39}
+		ttys1.Write([]byte{0x39, 0xff, 0x00})
+		binary.Write(ttys1, binary.LittleEndian, res)
+		binary.Write(ttys1, binary.LittleEndian, aurora.CRC(res))
+	}()
+
+	energy, err := i.DailyEnergy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if energy != 12345 {
+		t.Errorf("Expected %d got %d", 12345, energy)
+	}
+	if discarded := bus.Stats().DiscardedBytes; discarded != 3 {
+		t.Errorf("Expected %d discarded bytes got %d", 3, discarded)
+	}
+}
+
+func TestBusResyncBudget(t *testing.T) {
+	ttys0, ttys1 := net.Pipe()
+	defer ttys1.Close()
+	bus := aurora.NewBus(ttys0)
+	bus.Retry = nil
+	bus.ResyncBytes = 2
+	i := bus.Inverter(2)
+
+	go func() {
+		tmp := make([]byte, 10)
+		io.ReadFull(ttys1, tmp)
+		res := []byte{0, 6, 0, 0, 0x30, 0x39}
+		ttys1.Write([]byte{0x39, 0xff, 0x00})
+		binary.Write(ttys1, binary.LittleEndian, res)
+		binary.Write(ttys1, binary.LittleEndian, aurora.CRC(res))
+	}()
+
+	_, err := i.DailyEnergy()
+	if !errors.Is(err, aurora.ErrCRCFailure) {
+		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
+	}
+}
+
+func TestBusResyncNoDeadline(t *testing.T) {
+	// Without read deadlines there is no telling when the line has gone quiet,
+	// so the bad frame must come straight back rather than block for more
+	ttys0, ttys1 := mockSerialPair()
+	bus := aurora.NewBus(ttys0)
+	bus.Retry = nil
+	i := bus.Inverter(2)
+
+	go mockRespond(t, ttys1, []byte{0, 6, 0, 0, 0x30, 0x39}, true)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := i.DailyEnergyContext(ctx)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, aurora.ErrCRCFailure) {
+			t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("Expected the CRC failure without waiting for more input")
+	}
+	if discarded := bus.Stats().DiscardedBytes; discarded != 0 {
+		t.Errorf("Expected %d discarded bytes got %d", 0, discarded)
+	}
+}
+
+func TestBusDrain(t *testing.T) {
+	ttys0, ttys1 := net.Pipe()
+	defer ttys1.Close()
+	bus := aurora.NewBus(ttys0)
+	i := bus.Inverter(2)
+
+	go func() {
+		// Stale bytes on the line before the request is sent
+		ttys1.Write([]byte{0x00, 0x06, 0x01, 0x02})
+		mockRespond(t, ttys1, []byte{0, 6, 0, 0, 0x30, 0x39}, false)
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	energy, err := i.DailyEnergy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if energy != 12345 {
+		t.Errorf("Expected %d got %d", 12345, energy)
+	}
+	if discarded := bus.Stats().DiscardedBytes; discarded != 4 {
+		t.Errorf("Expected %d discarded bytes got %d", 4, discarded)
+	}
+}
+
+type mockFlusher struct {
+	io.ReadWriter
+	flushed int
+}
+
+func (m *mockFlusher) Flush() error {
+	m.flushed++
+	return nil
+}
+
+func TestBusDrainFlush(t *testing.T) {
+	ttys0, ttys1 := mockSerialPair()
+	conn := &mockFlusher{ReadWriter: ttys0}
+	i := aurora.NewBus(conn).Inverter(2)
+
+	go mockRespond(t, ttys1, []byte{0, 6, 0, 0, 0x30, 0x39}, false)
+
+	if _, err := i.DailyEnergy(); err != nil {
+		t.Fatal(err)
+	}
+	if conn.flushed != 1 {
+		t.Errorf("Expected %d flushes got %d", 1, conn.flushed)
+	}
+}
+
+// mockEchoRespond echoes the request back, as a half-duplex adapter would, before answering it
+func mockEchoRespond(t *testing.T, conn io.ReadWriter, res []byte) {
+	tmp := make([]byte, 10)
+	if _, err := io.ReadFull(conn, tmp); err != nil {
+		t.Error(err)
+		return
+	}
+
+	conn.Write(tmp)
+	binary.Write(conn, binary.LittleEndian, res)
+	binary.Write(conn, binary.LittleEndian, aurora.CRC(res))
+}
+
+func TestBusEcho(t *testing.T) {
+	tests := []struct {
+		Mode   aurora.EchoMode
+		Echo   bool
+		Expect error
+	}{
+		{Mode: aurora.EchoAuto, Echo: true},
+		{Mode: aurora.EchoAuto, Echo: false},
+		{Mode: aurora.EchoOn, Echo: true},
+		{Mode: aurora.EchoOn, Echo: false, Expect: aurora.ErrEchoMismatch},
+		{Mode: aurora.EchoOff, Echo: true, Expect: aurora.ErrCRCFailure},
+	}
+
+	for _, test := range tests {
+		ttys0, ttys1 := mockSerialPair()
+		bus := &aurora.Bus{Conn: ttys0, Echo: test.Mode}
+		i := bus.Inverter(2)
+
+		res := []byte{0, 6, 0, 0, 0x30, 0x39}
+		if test.Echo {
+			go mockEchoRespond(t, ttys1, res)
+		} else {
+			go mockRespond(t, ttys1, res, false)
+		}
+
+		energy, err := i.DailyEnergy()
+		if test.Expect != nil {
+			if !errors.Is(err, test.Expect) {
+				t.Errorf("Mode %d, echo %t: expected %v got %v", test.Mode, test.Echo, test.Expect, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Mode %d, echo %t: %v", test.Mode, test.Echo, err)
+		} else if energy != 12345 {
+			t.Errorf("Mode %d, echo %t: expected %d got %d", test.Mode, test.Echo, 12345, energy)
+		}
+
+		if echoes := bus.Stats().Echoes; test.Echo && echoes != 1 {
+			t.Errorf("Mode %d, echo %t: expected %d echoes got %d", test.Mode, test.Echo, 1, echoes)
+		}
+	}
+}
+
+func TestInverterConnChanged(t *testing.T) {
+	i := &aurora.Inverter{Address: 2}
+
+	for n := 0; n < 2; n++ {
+		ttys0, ttys1 := mockSerialPair()
+		i.Conn = ttys0
+		go mockRespond(t, ttys1, []byte{0, 6, 0, 0, 0x30, 0x39}, false)
+
+		if energy, err := i.DailyEnergy(); err != nil || energy != 12345 {
+			t.Errorf("Connection %d: expected %d got %d (%v)", n, 12345, energy, err)
+		}
+
+		expected := aurora.Stats{Requests: 1, Attempts: 1}
+		if stats := i.Stats(); stats != expected {
+			t.Errorf("Connection %d: expected %+v got %+v", n, expected, stats)
+		}
+	}
+}
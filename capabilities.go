@@ -0,0 +1,202 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package aurora
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+)
+
+// Capabilities records which of the known DSP parameters, cumulation periods
+// and counters an inverter answers, as found by Probe. A nil *Capabilities
+// supports everything so pollers can consult it before probing.
+type Capabilities struct {
+	Version  Version            `json:"version"`
+	DSP      []DSParameter      `json:"dsp"`
+	Energy   []CumulationPeriod `json:"energy"`
+	Counters []Counter          `json:"counters"`
+}
+
+// SupportsDSP returns true if the inverter answers requests for the parameter
+func (c *Capabilities) SupportsDSP(parameter DSParameter) bool {
+	if c == nil {
+		return true
+	}
+	for _, p := range c.DSP {
+		if p == parameter {
+			return true
+		}
+	}
+	return false
+}
+
+// SupportsEnergy returns true if the inverter answers requests for the
+// cumulated energy of the period
+func (c *Capabilities) SupportsEnergy(period CumulationPeriod) bool {
+	if c == nil {
+		return true
+	}
+	for _, p := range c.Energy {
+		if p == period {
+			return true
+		}
+	}
+	return false
+}
+
+// SupportsCounter returns true if the inverter answers requests for the counter
+func (c *Capabilities) SupportsCounter(counter Counter) bool {
+	if c == nil {
+		return true
+	}
+	for _, v := range c.Counters {
+		if v == counter {
+			return true
+		}
+	}
+	return false
+}
+
+// Probe asks the inverter for its version then every known DSP parameter,
+// cumulation period and counter, recording those it answers. CounterReset is
+// never asked for as reading it resets the partial counters. Anything other
+// than a transmission state coming back, such as a timeout, fails the probe.
+func (i *Inverter) Probe(ctx context.Context) (*Capabilities, error) {
+	version, err := i.VersionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Capabilities{Version: *version}
+
+	for _, parameter := range dsParameterValues {
+		ok, err := i.supports(ctx, GetDSP, parameter)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			c.DSP = append(c.DSP, parameter)
+		}
+	}
+
+	for _, period := range cumulationPeriodValues {
+		ok, err := i.supports(ctx, GetCumulatedEnergy, period)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			c.Energy = append(c.Energy, period)
+		}
+	}
+
+	for _, counter := range counterValues {
+		if counter == CounterReset {
+			continue
+		}
+		ok, err := i.supports(ctx, GetCounters, counter)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			c.Counters = append(c.Counters, counter)
+		}
+	}
+
+	return c, nil
+}
+
+// supports makes the request, returning true if it was answered. A busy
+// inverter still has the variable, any other transmission state means it
+// doesn't.
+func (i *Inverter) supports(ctx context.Context, command Command, arg Argument) (bool, error) {
+	_, err := i.CommunicateContext(ctx, command, arg)
+	if err == nil {
+		return true, nil
+	}
+
+	var state TransmissionState
+	if errors.As(err, &state) {
+		return state.Temporary(), nil
+	}
+	return false, err
+}
+
+// CapabilityCache holds capabilities by model, as told apart by Version, so
+// that only the first inverter of each model needs to be probed, or none at
+// all should the cache have been saved from an earlier run. It marshals to JSON
+// as a list of Capabilities and is safe for concurrent use.
+type CapabilityCache struct {
+	mu     sync.Mutex
+	models map[Version]*Capabilities
+}
+
+// Capabilities returns the capabilities of the inverter's model, probing the
+// inverter if the model hasn't been seen before
+func (c *CapabilityCache) Capabilities(ctx context.Context, inverter *Inverter) (*Capabilities, error) {
+	version, err := inverter.VersionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if caps := c.Lookup(*version); caps != nil {
+		return caps, nil
+	}
+
+	caps, err := inverter.Probe(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.Add(caps)
+	return caps, nil
+}
+
+// Lookup returns the capabilities of a model, or nil if it hasn't been seen
+func (c *CapabilityCache) Lookup(version Version) *Capabilities {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.models[version]
+}
+
+// Add records the capabilities of a model, replacing any already known
+func (c *CapabilityCache) Add(caps *Capabilities) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.models == nil {
+		c.models = map[Version]*Capabilities{}
+	}
+	c.models[caps.Version] = caps
+}
+
+// MarshalJSON implements json.Marshaler
+func (c *CapabilityCache) MarshalJSON() ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	list := make([]*Capabilities, 0, len(c.models))
+	for _, caps := range c.models {
+		list = append(list, caps)
+	}
+	sort.Slice(list, func(a, b int) bool { return versionKey(list[a].Version) < versionKey(list[b].Version) })
+	return json.Marshal(list)
+}
+
+// versionKey orders versions as their bytes on the wire
+func versionKey(v Version) uint32 {
+	return uint32(v.Model)<<24 | uint32(v.Regulation)<<16 | uint32(v.Transformer)<<8 | uint32(v.Type)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, adding to what is already known
+func (c *CapabilityCache) UnmarshalJSON(data []byte) error {
+	var list []*Capabilities
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	for _, caps := range list {
+		c.Add(caps)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package aurora_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/freman/go-aurora"
+	"github.com/freman/go-aurora/aurorasim"
+)
+
+// simulatedInverter returns an inverter talking to the given simulated one
+func simulatedInverter(t *testing.T, inverter *aurorasim.Inverter) *aurora.Inverter {
+	ttys0, ttys1 := net.Pipe()
+	t.Cleanup(func() { ttys0.Close() })
+	go aurorasim.New(inverter).Serve(ttys1)
+	return aurora.NewBus(ttys0).Inverter(inverter.Address)
+}
+
+func TestProbe(t *testing.T) {
+	sim := aurorasim.NewInverter(2)
+	sim.DSP = map[aurora.DSParameter]float32{
+		aurora.DSPGridPower:     960,
+		aurora.DSPInput1Voltage: 320,
+	}
+	delete(sim.Energy, aurora.CumulatedLast7Days)
+	sim.Counters[aurora.CounterPartial] = 60
+
+	caps, err := simulatedInverter(t, sim).Probe(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &aurora.Capabilities{
+		Version:  sim.Version,
+		DSP:      []aurora.DSParameter{aurora.DSPGridPower, aurora.DSPInput1Voltage},
+		Energy:   []aurora.CumulationPeriod{aurora.CumulatedDaily, aurora.CumulatedWeekly, aurora.CumulatedMonthly, aurora.CumulatedYearly, aurora.CumulatedTotal, aurora.CumulatedPartial},
+		Counters: []aurora.Counter{aurora.CounterTotal, aurora.CounterPartial, aurora.CounterGrid},
+	}
+	if !reflect.DeepEqual(expected, caps) {
+		t.Errorf("Expected %+v got %+v", expected, caps)
+	}
+
+	if sim.Counters[aurora.CounterPartial] != 60 {
+		t.Error("Expected probing to leave the partial counter alone")
+	}
+
+	if !caps.SupportsDSP(aurora.DSPGridPower) || caps.SupportsDSP(aurora.DSPInput2Voltage) {
+		t.Error("Unexpected DSP support")
+	}
+	if !caps.SupportsEnergy(aurora.CumulatedDaily) || caps.SupportsEnergy(aurora.CumulatedLast7Days) {
+		t.Error("Unexpected energy support")
+	}
+	if !caps.SupportsCounter(aurora.CounterGrid) || caps.SupportsCounter(aurora.CounterReset) {
+		t.Error("Unexpected counter support")
+	}
+
+	var unknown *aurora.Capabilities
+	if !unknown.SupportsDSP(aurora.DSPInput2Voltage) || !unknown.SupportsEnergy(aurora.CumulatedLast7Days) || !unknown.SupportsCounter(aurora.CounterGrid) {
+		t.Error("Expected nil capabilities to support everything")
+	}
+}
+
+func TestProbeTimeout(t *testing.T) {
+	ttys0, _ := net.Pipe()
+	defer ttys0.Close()
+
+	i := &aurora.Inverter{Conn: ttys0, Address: 2}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := i.Probe(ctx); err == nil {
+		t.Error("Expected error probing an inverter that isn't there")
+	}
+}
+
+func TestCapabilityCache(t *testing.T) {
+	sim := aurorasim.NewInverter(2)
+	i := simulatedInverter(t, sim)
+
+	var cache aurora.CapabilityCache
+	caps, err := cache.Capabilities(context.Background(), i)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A second inverter of the same model isn't probed
+	sim.Fail(aurora.GetDSP, aurora.TSCommandNotImplemented, 0)
+	again, err := cache.Capabilities(context.Background(), i)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != caps {
+		t.Error("Expected cached capabilities")
+	}
+
+	data, err := json.Marshal(&cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var loaded aurora.CapabilityCache
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	if got := loaded.Lookup(sim.Version); !reflect.DeepEqual(caps, got) {
+		t.Errorf("Expected %+v got %+v", caps, got)
+	}
+	if got := loaded.Lookup(aurora.Version{}); got != nil {
+		t.Errorf("Expected nothing for an unknown model got %+v", got)
+	}
+}
@@ -11,7 +11,7 @@ import (
 	"time"
 
 	"github.com/freman/go-aurora"
-	"github.com/tarm/serial"
+	"github.com/freman/go-aurora/transport"
 )
 
 const errorStringFormat = "String %d didn't reach %f, the highest recorded output current was %f"
@@ -55,11 +55,12 @@ func sendMail(username, password, server, from, recipient string, lines []string
 }
 
 func main() {
-	fPort := flag.String("p", "/dev/ttyUSB0", "Serial port")
+	fPort := flag.String("p", "/dev/ttyUSB0", "Serial port or URL (serial://, tcp://, rfc2217://)")
 	fString1 := flag.Float64("1", 4, "Minimum current threshold string 1")
 	fString2 := flag.Float64("2", 4, "Minimum current threshold string 2")
 	fCheckStart := flag.Int("s", 9, "Start checking at this hour")
 	fCheckEnd := flag.Int("e", 17, "Stop checking at this hour")
+	fSeverity := flag.String("severity", "warning", "Alert on inverter states at least this severe (info, warning, error, critical)")
 
 	fServer := flag.String("m", "localhost:25", "SMTP server")
 	fUsername := flag.String("u", "", "SMTP auth username")
@@ -79,17 +80,17 @@ func main() {
 		*fPassword = os.Getenv("PASSWORD")
 	}
 
-	sendMail(*fUsername, *fPassword, *fServer, *fSender, *fRecipient, []string{"Starting up..."})
-
-	options := &serial.Config{
-		Name:   *fPort,
-		Baud:   19200,
-		Parity: serial.ParityNone,
+	var minSeverity aurora.Severity
+	if err := minSeverity.UnmarshalText([]byte(*fSeverity)); err != nil {
+		log.Fatal(err)
 	}
 
+	sendMail(*fUsername, *fPassword, *fServer, *fSender, *fRecipient, []string{"Starting up..."})
+
 	string1Max := float64(0)
 	string2Max := float64(0)
 	haveChecked := false
+	var alerted *aurora.State
 
 	for {
 		if tomorrow := time.Now().Hour() > *fCheckEnd; tomorrow || time.Now().Hour() < *fCheckStart {
@@ -113,9 +114,9 @@ func main() {
 		}
 
 		func() {
-			port, err := serial.OpenPort(options)
+			port, err := transport.Dial(*fPort)
 			if err != nil {
-				log.Printf("serial.Open: %v", err)
+				log.Printf("transport.Dial: %v", err)
 				return
 			}
 
@@ -132,6 +133,32 @@ func main() {
 				return
 			}
 
+			state, err := inverter.State()
+			if err != nil {
+				log.Printf("inverter.State: %v", err)
+				return
+			}
+
+			// Alert once each time the state changes to one severe enough
+			if state.Severity() < minSeverity {
+				alerted = nil
+			} else if alerted == nil || *alerted != *state {
+				alerted = state
+				lines := []string{fmt.Sprintf("Inverter state is %s (%s): %v", state.Category(), state.Severity(), state)}
+				if state.NeedsReset() {
+					lines = append(lines, "The inverter needs a manual reset")
+				}
+				if state.Alarm != aurora.AlarmNone {
+					alarm := state.Alarm.Info()
+					lines = append(lines,
+						fmt.Sprintf("Alarm %s (%s)", alarm, alarm.Severity),
+						"Cause: "+alarm.Cause,
+						"Action: "+alarm.Action,
+					)
+				}
+				sendMail(*fUsername, *fPassword, *fServer, *fSender, *fRecipient, lines)
+			}
+
 			c1, err := inverter.Input1Current()
 			if err != nil {
 				log.Printf("inverter.Input1Current: %v", err)
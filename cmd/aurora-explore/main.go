@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/freman/go-aurora"
+	"github.com/freman/go-aurora/transport"
+)
+
+// sweep is every index asked of an inverter at one time, as saved with -o
+type sweep struct {
+	Time     time.Time  `json:"time"`
+	Address  byte       `json:"address"`
+	DSP      []*reading `json:"dsp"`
+	Commands []*reading `json:"commands,omitempty"`
+}
+
+// reading is the response to a single request along with the ways its data
+// could be interpreted
+type reading struct {
+	Index byte   `json:"index"`
+	Name  string `json:"name,omitempty"` // Name of the index if it is known
+	State string `json:"state"`          // Transmission state, or why there's no response
+	Data  string `json:"data,omitempty"` // Hex of the data following the state
+	Float string `json:"float,omitempty"`
+	Uint  uint32 `json:"uint32"`
+	ASCII string `json:"ascii,omitempty"`
+}
+
+// noResponse is the state of a request that went unanswered
+const noResponse = "no response"
+
+func main() {
+	fPort := flag.String("p", "/dev/ttyUSB0", "Serial port or URL (serial://, tcp://, rfc2217://)")
+	fAddress := flag.Uint("a", 2, "Inverter address")
+	fTimeout := flag.Duration("timeout", time.Second, "How long to wait for each response")
+	fCommands := flag.Bool("commands", false, "Also sweep every command byte, skipping those known to change settings. Unknown commands might change settings too!")
+	fOut := flag.String("o", "", "Save the sweep as JSON to this file for a later -diff")
+	fDiff := flag.Bool("diff", false, "Compare two saved sweeps given as arguments rather than sweep an inverter")
+	flag.Parse()
+
+	if *fDiff {
+		if flag.NArg() != 2 {
+			log.Fatal("-diff takes two sweeps to compare")
+		}
+		before, err := load(flag.Arg(0))
+		if err != nil {
+			log.Fatal(err)
+		}
+		after, err := load(flag.Arg(1))
+		if err != nil {
+			log.Fatal(err)
+		}
+		diff(os.Stdout, before, after)
+		return
+	}
+
+	port, err := transport.Dial(*fPort)
+	if err != nil {
+		log.Fatalf("transport.Dial: %v", err)
+	}
+	defer port.Close()
+
+	inverter := aurora.NewBus(port).Inverter(byte(*fAddress))
+	inverter.Retry = &aurora.RetryPolicy{Attempts: 2}
+
+	s := &sweep{
+		Time:    time.Now(),
+		Address: inverter.Address,
+	}
+
+	for index := 0; index < 256; index++ {
+		parameter := aurora.DSParameter(index)
+		r := explore(inverter, *fTimeout, aurora.GetDSP, parameter)
+		if parameter.Known() {
+			r.Name = parameter.String()
+		}
+		r.Index = byte(index)
+		s.DSP = append(s.DSP, r)
+		printReading(os.Stdout, "DSP", r)
+	}
+
+	if *fCommands {
+		for index := 0; index < 256; index++ {
+			command := aurora.Command(index)
+			spec, known := aurora.LookupCommand(command)
+			if known && spec.Writes {
+				continue
+			}
+
+			var args []aurora.Argument
+			if known {
+				// Ask for the first of whatever the command takes
+				args = spec.Args
+			}
+			r := explore(inverter, *fTimeout, command, args...)
+			if known {
+				r.Name = spec.Name
+			}
+			r.Index = byte(index)
+			s.Commands = append(s.Commands, r)
+			printReading(os.Stdout, "Command", r)
+		}
+	}
+
+	if *fOut != "" {
+		if err := save(*fOut, s); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// explore makes a request and interprets the response
+func explore(inverter *aurora.Inverter, timeout time.Duration, command aurora.Command, args ...aurora.Argument) *reading {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	response, err := inverter.RawContext(ctx, command, args...)
+	if errors.Is(err, aurora.ErrTimeout) {
+		return &reading{State: noResponse}
+	} else if err != nil {
+		return &reading{State: err.Error()}
+	}
+
+	data := response.Payload[2:]
+	state := response.State().String()
+	if spec, ok := aurora.LookupCommand(command); ok && spec.Layout == aurora.LayoutRaw {
+		data = response.Payload[:]
+		state = aurora.TSOk.String()
+	}
+
+	r := &reading{
+		State: state,
+		Data:  hex.EncodeToString(data),
+		ASCII: ascii(data),
+	}
+	if len(data) >= 4 {
+		r.Uint = binary.BigEndian.Uint32(data)
+		r.Float = strconv.FormatFloat(float64(math.Float32frombits(r.Uint)), 'g', -1, 32)
+	}
+	return r
+}
+
+// ascii returns the printable characters of the data, dots for the rest
+func ascii(data []byte) string {
+	out := make([]byte, len(data))
+	for n, b := range data {
+		if b < 32 || b > 126 {
+			b = '.'
+		}
+		out[n] = b
+	}
+	return string(out)
+}
+
+func printReading(w io.Writer, kind string, r *reading) {
+	if r.Data == "" {
+		fmt.Fprintf(w, "%s %3d %-32s %s\n", kind, r.Index, r.Name, r.State)
+		return
+	}
+	fmt.Fprintf(w, "%s %3d %-32s %-30s %-12s %14s %10d %q\n", kind, r.Index, r.Name, r.State, r.Data, r.Float, r.Uint, r.ASCII)
+}
+
+func save(path string, s *sweep) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "\t")
+	if err := enc.Encode(s); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func load(path string) (*sweep, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var s sweep
+	if err := json.NewDecoder(f).Decode(&s); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return &s, nil
+}
+
+// diff prints the indices whose state or data differ between the sweeps
+func diff(w io.Writer, before, after *sweep) {
+	fmt.Fprintf(w, "Comparing inverter %d at %s with inverter %d at %s\n", before.Address, before.Time.Format(time.RFC3339), after.Address, after.Time.Format(time.RFC3339))
+	diffReadings(w, "DSP", before.DSP, after.DSP)
+	diffReadings(w, "Command", before.Commands, after.Commands)
+}
+
+func diffReadings(w io.Writer, kind string, before, after []*reading) {
+	was := map[byte]*reading{}
+	for _, r := range before {
+		was[r.Index] = r
+	}
+
+	for _, r := range after {
+		b, ok := was[r.Index]
+		if !ok || (b.State == r.State && b.Data == r.Data) {
+			continue
+		}
+		fmt.Fprint(w, "- ")
+		printReading(w, kind, b)
+		fmt.Fprint(w, "+ ")
+		printReading(w, kind, r)
+	}
+}
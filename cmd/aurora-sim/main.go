@@ -0,0 +1,106 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+	"time"
+
+	"github.com/freman/go-aurora/aurorasim"
+
+	"github.com/BurntSushi/toml"
+)
+
+func main() {
+	fScenario := flag.String("scenario", "", "Path to a scenario file, a sunny day with a ground fault at lunch if not given")
+	fListen := flag.String("listen", "", "Override where to listen, pty or a TCP address such as :4001")
+	flag.Parse()
+
+	s := defaultScenario()
+	if *fScenario != "" {
+		s = &scenario{
+			Listen: "pty",
+			Speed:  1,
+			Tick:   duration{time.Second},
+		}
+		if _, err := toml.DecodeFile(*fScenario, s); err != nil {
+			log.Fatalf("Unable to parse scenario due to %v", err)
+		}
+	}
+	if *fListen != "" {
+		s.Listen = *fListen
+	}
+
+	c := newClock(s.Start, s.Speed)
+	sim := &aurorasim.Simulator{Echo: s.Echo}
+	var virtuals []*virtual
+	for _, inverter := range s.Inverters {
+		v, err := newVirtual(inverter, c.Now)
+		if err != nil {
+			log.Fatalf("Invalid scenario: %v", err)
+		}
+		virtuals = append(virtuals, v)
+		sim.Add(v.Inverter)
+	}
+
+	go run(c, s.Tick.Duration, virtuals)
+
+	if s.Listen == "pty" {
+		servePty(sim, len(virtuals))
+		return
+	}
+	serveTCP(sim, s.Listen, len(virtuals))
+}
+
+// run updates the virtual inverters every tick
+func run(c *clock, tick time.Duration, virtuals []*virtual) {
+	last := c.Now()
+	for _, v := range virtuals {
+		v.update(last, 0)
+	}
+
+	for range time.Tick(tick) {
+		now := c.Now()
+		for _, v := range virtuals {
+			v.update(now, now.Sub(last))
+		}
+		last = now
+	}
+}
+
+func servePty(sim *aurorasim.Simulator, inverters int) {
+	master, name, slave, err := openPty()
+	if err != nil {
+		log.Fatalf("Unable to open pty: %v", err)
+	}
+	defer slave.Close()
+	defer master.Close()
+
+	log.Printf("Serving %d inverters on %s", inverters, name)
+	if err := sim.Serve(master); err != nil {
+		log.Fatalf("Serve: %v", err)
+	}
+}
+
+func serveTCP(sim *aurorasim.Simulator, addr string, inverters int) {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Fatalf("Unable to listen: %v", err)
+	}
+
+	log.Printf("Serving %d inverters on tcp://%s", inverters, l.Addr())
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			log.Fatalf("Accept: %v", err)
+		}
+
+		go func() {
+			defer conn.Close()
+			log.Printf("Connection from %s", conn.RemoteAddr())
+			if err := sim.Serve(conn); err != nil {
+				log.Printf("Connection from %s: %v", conn.RemoteAddr(), err)
+			}
+		}()
+	}
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+// +build !linux
+
+package main
+
+import (
+	"errors"
+	"os"
+)
+
+func openPty() (*os.File, string, *os.File, error) {
+	return nil, "", nil, errors.New("pseudo-terminals are only supported on linux, listen on a TCP address instead")
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/freman/go-aurora"
+	"github.com/freman/go-aurora/aurorasim"
+)
+
+// scenario describes a day in the life of some virtual inverters
+type scenario struct {
+	Listen    string    // pty, or a TCP address such as :4001
+	Echo      bool      // Echo requests as a half-duplex adapter would
+	Start     timeOfDay // Simulated time of day to start at
+	Speed     float64   // Simulated seconds per real second
+	Tick      duration  // How often to update readings, in real time
+	Inverters []inverterScenario
+}
+
+type inverterScenario struct {
+	Address      byte
+	SerialNumber string
+	PartNumber   string
+	PeakPower    float64 // Watts at solar noon
+	Sunrise      timeOfDay
+	Sunset       timeOfDay
+	Events       []event
+}
+
+// event changes the state of an inverter at a time of day, states and alarms
+// are given by name (as printed by the aurora package) or number
+type event struct {
+	At       timeOfDay
+	Global   string
+	Inverter string
+	Alarm    string // Raises the alarm
+	Clear    bool   // Clears the current alarm
+}
+
+type timeOfDay struct {
+	time.Duration
+}
+
+type duration struct {
+	time.Duration
+}
+
+func (t *timeOfDay) UnmarshalText(text []byte) error {
+	parsed, err := time.Parse("15:04", string(text))
+	if err != nil {
+		return err
+	}
+	t.Duration = time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute
+	return nil
+}
+
+func (d *duration) UnmarshalText(text []byte) (err error) {
+	d.Duration, err = time.ParseDuration(string(text))
+	return
+}
+
+// defaultScenario is a sunny day for a single inverter that develops a ground
+// fault over lunch
+func defaultScenario() *scenario {
+	return &scenario{
+		Listen: "pty",
+		Start:  timeOfDay{5*time.Hour + 30*time.Minute},
+		Speed:  60,
+		Tick:   duration{time.Second},
+		Inverters: []inverterScenario{{
+			Address:      2,
+			SerialNumber: "100002",
+			PeakPower:    3000,
+			Sunrise:      timeOfDay{6 * time.Hour},
+			Sunset:       timeOfDay{18 * time.Hour},
+			Events: []event{
+				{At: timeOfDay{0}, Global: "Waiting Sun", Inverter: "Stand By"},
+				{At: timeOfDay{6 * time.Hour}, Global: "Run", Inverter: "Run"},
+				{At: timeOfDay{13 * time.Hour}, Global: "Ground Fault", Inverter: "Leak Fail", Alarm: "Ground Fault (18)"},
+				{At: timeOfDay{13*time.Hour + 30*time.Minute}, Global: "Run", Inverter: "Run", Clear: true},
+				{At: timeOfDay{18 * time.Hour}, Global: "Waiting Sun", Inverter: "Stand By"},
+			},
+		}},
+	}
+}
+
+// lookup finds the value of a state by name or number
+func lookup(name string, str func(byte) string) (byte, error) {
+	if n, err := strconv.ParseUint(name, 10, 8); err == nil {
+		return byte(n), nil
+	}
+	for n := 0; n < 256; n++ {
+		if strings.EqualFold(str(byte(n)), name) {
+			return byte(n), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown state %q", name)
+}
+
+func globalState(b byte) string   { return aurora.GlobalState(b).String() }
+func inverterState(b byte) string { return aurora.InverterState(b).String() }
+func alarmState(b byte) string    { return aurora.AlarmState(b).String() }
+
+// virtual drives a simulated inverter through its scenario
+type virtual struct {
+	*aurorasim.Inverter
+	scenario inverterScenario
+	next     int           // Index of the next event
+	tod      time.Duration // Time of day as of the last update
+	energy   float64       // Watt hours not yet added to the counters
+	runTime  time.Duration // Time powered up
+	gridTime time.Duration // Time connected to the grid
+	last     time.Time     // Simulated time of the last update
+	today    uint32        // Watt hours added since midnight
+	days     []uint32      // Watt hours of up to the 6 days before today
+}
+
+// clock keeps simulated time
+type clock struct {
+	start time.Time
+	real  time.Time
+	speed float64
+}
+
+func newClock(start timeOfDay, speed float64) *clock {
+	now := time.Now()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	return &clock{start: midnight.Add(start.Duration), real: now, speed: speed}
+}
+
+func (c *clock) Now() time.Time {
+	return c.start.Add(time.Duration(float64(time.Since(c.real)) * c.speed))
+}
+
+func newVirtual(s inverterScenario, now func() time.Time) (*virtual, error) {
+	inverter := aurorasim.NewInverter(s.Address)
+	inverter.Now = now
+	if s.SerialNumber != "" {
+		inverter.SerialNumber = s.SerialNumber
+	}
+	if s.PartNumber != "" {
+		inverter.PartNumber = s.PartNumber
+	}
+
+	// Catch mistakes in the scenario before we start
+	for _, e := range s.Events {
+		if _, _, _, err := e.states(); err != nil {
+			return nil, fmt.Errorf("inverter %d at %v: %v", s.Address, e.At.Duration, err)
+		}
+	}
+
+	return &virtual{Inverter: inverter, scenario: s}, nil
+}
+
+func (e *event) states() (global, inverter, alarm byte, err error) {
+	if e.Global != "" {
+		if global, err = lookup(e.Global, globalState); err != nil {
+			return
+		}
+	}
+	if e.Inverter != "" {
+		if inverter, err = lookup(e.Inverter, inverterState); err != nil {
+			return
+		}
+	}
+	if e.Alarm != "" {
+		alarm, err = lookup(e.Alarm, alarmState)
+	}
+	return
+}
+
+// update brings the inverter up to date with the scenario at the given time,
+// having been elapsed since the last update
+func (v *virtual) update(now time.Time, elapsed time.Duration) {
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	tod := now.Sub(midnight)
+	if tod < v.tod {
+		// A new day dawns
+		v.next = 0
+	}
+	v.tod = tod
+	v.rollOver(now)
+
+	state := v.State
+	for v.next < len(v.scenario.Events) && v.scenario.Events[v.next].At.Duration <= tod {
+		e := v.scenario.Events[v.next]
+		global, inverter, alarm, _ := e.states()
+		if e.Global != "" {
+			state.Global = aurora.GlobalState(global)
+		}
+		if e.Inverter != "" {
+			state.Inverter = aurora.InverterState(inverter)
+		}
+		if e.Clear {
+			v.Clear()
+			state.Alarm = aurora.AlarmNone
+		}
+		if e.Alarm != "" {
+			v.Raise(aurora.AlarmState(alarm))
+			state.Alarm = aurora.AlarmState(alarm)
+		}
+		v.next++
+	}
+
+	power := 0.0
+	if state.Global == aurora.GSRun {
+		power = v.solar(tod)
+		state.Channel1, state.Channel2 = aurora.DCDCMPPT, aurora.DCDCMPPT
+	} else {
+		state.Channel1, state.Channel2 = aurora.DCDCOff, aurora.DCDCOff
+	}
+	v.SetState(state)
+
+	// Two strings sharing the load with the panels a little above grid voltage
+	const gridVoltage, panelVoltage = 240, 320
+	efficiency := 0.96
+	v.SetDSP(aurora.DSPGridPower, float32(power))
+	v.SetDSP(aurora.DSPGridVoltage, gridVoltage)
+	v.SetDSP(aurora.DSPGridCurrent, float32(power/gridVoltage))
+	v.SetDSP(aurora.DSPInput1Voltage, panelVoltage)
+	v.SetDSP(aurora.DSPInput2Voltage, panelVoltage)
+	v.SetDSP(aurora.DSPInput1Current, float32(power/efficiency/2/panelVoltage))
+	v.SetDSP(aurora.DSPInput2Current, float32(power/efficiency/2/panelVoltage))
+	v.SetDSP(aurora.DSPInverterTemperature, float32(25+15*power/v.peak()))
+	v.SetDSP(aurora.DSPBoosterTemperature, float32(25+12*power/v.peak()))
+
+	v.runTime += elapsed
+	if power > 0 {
+		v.gridTime += elapsed
+	}
+	v.SetCounter(aurora.CounterTotal, uint32(v.runTime.Seconds()))
+	v.SetCounter(aurora.CounterGrid, uint32(v.gridTime.Seconds()))
+
+	v.energy += power * elapsed.Hours()
+	if wh := math.Floor(v.energy); wh > 0 {
+		v.AddEnergy(uint32(wh))
+		v.today += uint32(wh)
+		v.energy -= wh
+	}
+}
+
+// rollOver zeroes the cumulated energy of the days, weeks, months and years that
+// have ended since the last update, as the inverter does, and drops the days
+// that have fallen out of the last 7
+func (v *virtual) rollOver(now time.Time) {
+	last := v.last
+	v.last = now
+	if last.IsZero() {
+		return
+	}
+
+	ly, lm, ld := last.Date()
+	ny, nm, nd := now.Date()
+	if ly == ny && lm == nm && ld == nd {
+		return
+	}
+
+	// Days that went by without an update produced nothing
+	v.days = append(v.days, v.today)
+	for day := time.Date(ly, lm, ld+2, 0, 0, 0, 0, now.Location()); !day.After(now); day = day.AddDate(0, 0, 1) {
+		v.days = append(v.days, 0)
+	}
+	if len(v.days) > 6 {
+		v.days = v.days[len(v.days)-6:]
+	}
+	v.today = 0
+
+	var last7 uint32
+	for _, wh := range v.days {
+		last7 += wh
+	}
+	v.SetEnergy(aurora.CumulatedDaily, 0)
+	v.SetEnergy(aurora.CumulatedLast7Days, last7)
+
+	lastYear, lastWeek := last.ISOWeek()
+	year, week := now.ISOWeek()
+	if lastYear != year || lastWeek != week {
+		v.SetEnergy(aurora.CumulatedWeekly, 0)
+	}
+	if ly != ny || lm != nm {
+		v.SetEnergy(aurora.CumulatedMonthly, 0)
+	}
+	if ly != ny {
+		v.SetEnergy(aurora.CumulatedYearly, 0)
+	}
+}
+
+// solar returns the output at the given time of day, following a sine curve
+// from sunrise to sunset
+func (v *virtual) solar(tod time.Duration) float64 {
+	sunrise, sunset := v.scenario.Sunrise.Duration, v.scenario.Sunset.Duration
+	if tod <= sunrise || tod >= sunset {
+		return 0
+	}
+	return v.peak() * math.Sin(math.Pi*float64(tod-sunrise)/float64(sunset-sunrise))
+}
+
+func (v *virtual) peak() float64 {
+	if v.scenario.PeakPower <= 0 {
+		return 3000
+	}
+	return v.scenario.PeakPower
+}
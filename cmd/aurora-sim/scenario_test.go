@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/freman/go-aurora"
+)
+
+// simulate runs the virtual inverter from start to end a minute at a time
+func simulate(v *virtual, start, end time.Time) {
+	for now := start; !now.After(end); now = now.Add(time.Minute) {
+		v.update(now, time.Minute)
+	}
+}
+
+func TestVirtualRollOver(t *testing.T) {
+	s := defaultScenario().Inverters[0]
+	s.Events = nil // Sunny all day
+	v, err := newVirtual(s, time.Now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v.State.Global = aurora.GSRun
+
+	// New year's eve, a Thursday, through to new year's day in the same ISO week
+	eve := time.Date(2026, time.December, 31, 0, 0, 0, 0, time.UTC)
+	simulate(v, eve, eve.Add(23*time.Hour+59*time.Minute))
+	day := v.Energy[aurora.CumulatedDaily]
+	if day == 0 {
+		t.Fatal("Expected energy to be produced")
+	}
+	for _, period := range []aurora.CumulationPeriod{aurora.CumulatedWeekly, aurora.CumulatedLast7Days, aurora.CumulatedMonthly, aurora.CumulatedYearly, aurora.CumulatedTotal} {
+		if v.Energy[period] != day {
+			t.Errorf("Expected %s energy of %d got %d", period, day, v.Energy[period])
+		}
+	}
+
+	simulate(v, eve.Add(24*time.Hour), eve.Add(24*time.Hour+time.Minute))
+	expected := map[aurora.CumulationPeriod]uint32{
+		aurora.CumulatedDaily:     0,
+		aurora.CumulatedWeekly:    day,
+		aurora.CumulatedLast7Days: day,
+		aurora.CumulatedMonthly:   0,
+		aurora.CumulatedYearly:    0,
+		aurora.CumulatedTotal:     day,
+	}
+	for period, wh := range expected {
+		if v.Energy[period] != wh {
+			t.Errorf("After midnight expected %s energy of %d got %d", period, wh, v.Energy[period])
+		}
+	}
+
+	// A week later only the days since count towards the last 7
+	simulate(v, eve.Add(8*24*time.Hour), eve.Add(8*24*time.Hour+time.Minute))
+	if wh := v.Energy[aurora.CumulatedLast7Days]; wh != 0 {
+		t.Errorf("Expected last 7 days energy of %d got %d", 0, wh)
+	}
+	if wh := v.Energy[aurora.CumulatedWeekly]; wh != 0 {
+		t.Errorf("Expected weekly energy of %d got %d", 0, wh)
+	}
+	if wh := v.Energy[aurora.CumulatedTotal]; wh != day {
+		t.Errorf("Expected total energy of %d got %d", day, wh)
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/freman/go-aurora"
+	"github.com/freman/go-aurora/transport"
+)
+
+// record is an exchange as exported in JSON
+type record struct {
+	Time     time.Time   `json:"time"`
+	Address  byte        `json:"address"`
+	Command  string      `json:"command"`
+	Argument string      `json:"argument,omitempty"`
+	Value    interface{} `json:"value,omitempty"`
+	Error    string      `json:"error,omitempty"`
+}
+
+func main() {
+	fPort := flag.String("p", "/dev/ttyUSB0", "Serial port or URL (serial://, tcp://, rfc2217://)")
+	fAddress := flag.Int("a", -1, "Only show exchanges with this inverter address")
+	fJSON := flag.Bool("json", false, "Print exchanges as JSON lines")
+	fErrors := flag.Bool("errors", true, "Show exchanges that failed, including unanswered requests")
+	flag.Parse()
+
+	port, err := transport.Dial(*fPort)
+	if err != nil {
+		log.Fatalf("transport.Dial: %v", err)
+	}
+
+	defer port.Close()
+
+	// Never write to the port, someone else is master of this bus
+	sniffer := aurora.NewSniffer(port)
+	enc := json.NewEncoder(os.Stdout)
+
+	for {
+		exchange, err := sniffer.Next()
+		if err == io.EOF {
+			return
+		} else if err != nil {
+			log.Fatalf("sniffer.Next: %v", err)
+		}
+
+		if *fAddress >= 0 && exchange.Address() != byte(*fAddress) {
+			continue
+		}
+		if exchange.Err != nil && !*fErrors {
+			continue
+		}
+
+		if !*fJSON {
+			fmt.Printf("%s %s\n", exchange.Time.Format("15:04:05.000"), exchange)
+			continue
+		}
+
+		r := record{
+			Time:    exchange.Time,
+			Address: exchange.Address(),
+			Command: exchange.Command().String(),
+			Value:   exchange.Value,
+		}
+		if arg := exchange.Argument(); arg != nil {
+			r.Argument = fmt.Sprint(arg)
+		}
+		if exchange.Err != nil {
+			r.Error = exchange.Err.Error()
+		}
+		enc.Encode(r)
+	}
+}
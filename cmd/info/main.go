@@ -1,12 +1,15 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
+	"os"
 
 	"github.com/freman/go-aurora"
-	"github.com/tarm/serial"
+	"github.com/freman/go-aurora/transport"
 )
 
 func errCheck(what string, err error) {
@@ -16,34 +19,60 @@ func errCheck(what string, err error) {
 }
 
 func main() {
-	fPort := flag.String("p", "/dev/ttyUSB0", "Serial port")
+	fPort := flag.String("p", "/dev/ttyUSB0", "Serial port or URL (serial://, tcp://, rfc2217://)")
+	fAddress := flag.Uint("a", 2, "Inverter address")
+	fScan := flag.Bool("scan", false, "Scan the bus for inverters rather than query one")
+	fFrom := flag.Uint("from", 2, "First address to scan")
+	fTo := flag.Uint("to", 63, "Last address to scan")
+	fCapture := flag.String("capture", "", "Record everything sent and received to this file")
+	fJSON := flag.Bool("json", false, "Print the device info as JSON")
 	flag.Parse()
 
-	options := &serial.Config{
-		Name:   *fPort,
-		Baud:   19200,
-		Parity: serial.ParityNone,
+	if *fAddress > 255 || *fFrom > 255 || *fTo > 255 {
+		log.Fatal("Addresses range from 0 to 255")
+	}
+	if *fScan && *fFrom > *fTo {
+		log.Fatalf("Nothing to scan from %d to %d", *fFrom, *fTo)
 	}
 
-	port, err := serial.OpenPort(options)
+	port, err := transport.Dial(*fPort)
 	if err != nil {
-		log.Fatalf("serial.Open: %v", err)
+		log.Fatalf("transport.Dial: %v", err)
+	}
+
+	if *fCapture != "" {
+		f, err := os.Create(*fCapture)
+		if err != nil {
+			log.Fatalf("Unable to create capture: %v", err)
+		}
+		defer f.Close()
+		port = transport.Record(port, f)
 	}
 
 	defer port.Close()
 
-	inverter := &aurora.Inverter{
-		Conn:    port,
-		Address: 2,
+	bus := aurora.NewBus(port)
+
+	if *fScan {
+		scan(bus, byte(*fFrom), byte(*fTo))
+		return
 	}
 
+	inverter := bus.Inverter(byte(*fAddress))
+
 	errCheck("CommCheck", inverter.CommCheck())
 
-	configuration, err := inverter.Configuration()
-	errCheck("Configuration", err)
+	info, err := inverter.Identify()
+	errCheck("Identify", err)
 
-	version, err := inverter.Version()
-	errCheck("Version", err)
+	if *fJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "\t")
+		if err := enc.Encode(info); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 
 	time, err := inverter.GetTime()
 	errCheck("GetTime", err)
@@ -54,26 +83,28 @@ func main() {
 	boosterTemp, err := inverter.BoosterTemperature()
 	errCheck("BoosterTemperature", err)
 
-	year, week, err := inverter.ManufactureDate()
-	errCheck("ManufactureDate", err)
-
-	serialNumber, err := inverter.SerialNumber()
-	errCheck("SerialNumber", err)
-
-	fmt.Printf(`%v
-Serial #: %s (Manufactured week %s of 20%s)
-Inverter time: %v
-Temperature %fºC / %fºC inverter/booster
-String Configuration: %v
+	fmt.Printf(`%vInverter time: %v
+Temperature %v / %v inverter/booster
 `,
-		version,
-		serialNumber,
-		week,
-		year,
+		info,
 		time,
 		inverterTemp,
 		boosterTemp,
-		configuration,
 	)
+}
+
+func scan(bus *aurora.Bus, from, to byte) {
+	log.Printf("Scanning addresses %d to %d", from, to)
+
+	units, err := bus.Scan(context.Background(), from, to)
+	errCheck("Scan", err)
 
+	if len(units) == 0 {
+		fmt.Println("No inverters found")
+		return
+	}
+
+	for _, unit := range units {
+		fmt.Println(unit)
+	}
 }
@@ -1,21 +1,26 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/freman/go-aurora"
+	"github.com/freman/go-aurora/transport"
 
 	"github.com/BurntSushi/toml"
-	log "github.com/Sirupsen/logrus"
-	"github.com/matryer/try"
+	log "github.com/sirupsen/logrus"
 	"github.com/tarm/serial"
 )
 
@@ -33,14 +38,120 @@ type result struct {
 	Input1Current       float32
 	Input2Voltage       float32
 	Input2Current       float32
-	Joules              uint16
-	DailyEnergy         uint32
-	WeeklyEnergy        uint32
-	MonthlyEnergy       uint32
-	YearlyEnergy        uint32
-	TotalEnergy         uint32
+	Joules              aurora.Joules
+	DailyEnergy         aurora.WattHours
+	WeeklyEnergy        aurora.WattHours
+	MonthlyEnergy       aurora.WattHours
+	YearlyEnergy        aurora.WattHours
+	TotalEnergy         aurora.WattHours
 	TotalRunTime        duration
 	SerialNumber        string
+	Category            aurora.StateCategory
+	Severity            aurora.Severity
+	Metrics             map[string]float64 `json:",omitempty"` // Those configured by name, in the unit of the metric
+}
+
+// dspParameters are the DSP values the monitor reports
+var dspParameters = []aurora.DSParameter{
+	aurora.DSPBoosterTemperature,
+	aurora.DSPInverterTemperature,
+	aurora.DSPFrequency,
+	aurora.DSPGridVoltage,
+	aurora.DSPGridCurrent,
+	aurora.DSPGridPower,
+	aurora.DSPInput1Voltage,
+	aurora.DSPInput1Current,
+	aurora.DSPInput2Voltage,
+	aurora.DSPInput2Current,
+}
+
+// fill copies the readings of the snapshot into the result, logging those that
+// failed and leaving them at zero
+func (r *result) fill(s *aurora.Snapshot, logger *log.Entry) {
+	dsp := func(parameter aurora.DSParameter, v *float32) {
+		if reading, ok := s.DSP[parameter]; ok {
+			if reading.Err != nil {
+				logger.WithError(reading.Err).Warningf("Unable to read %s", parameter)
+			}
+			*v = reading.Value
+		}
+	}
+	energy := func(period aurora.CumulationPeriod, v *aurora.WattHours) {
+		if reading, ok := s.Energy[period]; ok {
+			if reading.Err != nil {
+				logger.WithError(reading.Err).Warningf("Unable to read %s energy", period)
+			}
+			*v = reading.Value
+		}
+	}
+	counter := func(counter aurora.Counter, v *duration) {
+		if reading, ok := s.Counters[counter]; ok {
+			if reading.Err != nil {
+				logger.WithError(reading.Err).Warningf("Unable to read %s", counter)
+			}
+			v.Duration = reading.Value
+		}
+	}
+
+	if s.State.Valid() {
+		r.Category = s.State.Value.Category()
+		r.Severity = s.State.Value.Severity()
+	}
+
+	dsp(aurora.DSPBoosterTemperature, &r.BoosterTemperature)
+	dsp(aurora.DSPInverterTemperature, &r.InverterTemperature)
+	dsp(aurora.DSPFrequency, &r.Frequency)
+	dsp(aurora.DSPGridVoltage, &r.GridVoltage)
+	dsp(aurora.DSPGridCurrent, &r.GridCurrent)
+	dsp(aurora.DSPGridPower, &r.GridPower)
+	dsp(aurora.DSPInput1Voltage, &r.Input1Voltage)
+	dsp(aurora.DSPInput1Current, &r.Input1Current)
+	dsp(aurora.DSPInput2Voltage, &r.Input2Voltage)
+	dsp(aurora.DSPInput2Current, &r.Input2Current)
+	counter(aurora.CounterGrid, &r.GridRunTime)
+	counter(aurora.CounterTotal, &r.TotalRunTime)
+	energy(aurora.CumulatedDaily, &r.DailyEnergy)
+	energy(aurora.CumulatedWeekly, &r.WeeklyEnergy)
+	energy(aurora.CumulatedMonthly, &r.MonthlyEnergy)
+	energy(aurora.CumulatedYearly, &r.YearlyEnergy)
+	energy(aurora.CumulatedTotal, &r.TotalEnergy)
+
+	if s.Joules.Err != nil {
+		logger.WithError(s.Joules.Err).Warning("Unable to read Joules")
+	}
+	r.Joules = s.Joules.Value
+}
+
+// lookupMetrics returns the metrics with the given names
+func lookupMetrics(names []string) ([]*aurora.Metric, error) {
+	metrics := make([]*aurora.Metric, 0, len(names))
+	for _, name := range names {
+		m, ok := aurora.LookupMetric(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown metric %q", name)
+		}
+		metrics = append(metrics, m)
+	}
+	return metrics, nil
+}
+
+// read reads each of the metrics the inverter supports into the result,
+// logging those that failed and leaving them out
+func (r *result) read(ctx context.Context, inverter *aurora.Inverter, metrics []*aurora.Metric, capabilities *aurora.Capabilities, logger *log.Entry) {
+	for _, m := range metrics {
+		if !m.SupportedBy(capabilities) {
+			continue
+		}
+		value, err := inverter.Read(ctx, m)
+		if err != nil {
+			logger.WithError(err).Warningf("Unable to read %s", m)
+			continue
+		}
+		if r.Metrics == nil {
+			r.Metrics = map[string]float64{}
+		}
+		r.Metrics[m.Name] = value.Value
+	}
 }
 
 type results struct {
@@ -57,9 +168,40 @@ type duration struct {
 	time.Duration
 }
 
-func (o *serialConfig) Normalise() *serial.Config {
-	o.Config.ReadTimeout = o.ReadTimeout.Duration
-	return &o.Config
+var serialParities = map[serial.Parity]string{
+	serial.ParityNone:  "none",
+	serial.ParityOdd:   "odd",
+	serial.ParityEven:  "even",
+	serial.ParityMark:  "mark",
+	serial.ParitySpace: "space",
+}
+
+// URL describes the port for transport.Dial, reads are capped at readTimeout
+// unless the configuration says otherwise
+func (o *serialConfig) URL(readTimeout time.Duration) string {
+	query := url.Values{}
+	if o.Baud != 0 {
+		query.Set("baud", strconv.Itoa(o.Baud))
+	}
+	if o.Size != 0 {
+		query.Set("databits", strconv.Itoa(int(o.Size)))
+	}
+	if o.Parity != 0 {
+		parity, ok := serialParities[o.Parity]
+		if !ok {
+			parity = string(rune(o.Parity))
+		}
+		query.Set("parity", parity)
+	}
+	if o.StopBits != 0 {
+		query.Set("stopbits", strconv.Itoa(int(o.StopBits)))
+	}
+	if o.ReadTimeout.Duration != 0 {
+		readTimeout = o.ReadTimeout.Duration
+	}
+	query.Set("readtimeout", readTimeout.String())
+
+	return (&url.URL{Scheme: "serial", Path: o.Name, RawQuery: query.Encode()}).String()
 }
 
 func (d *duration) UnmarshalText(text []byte) (err error) {
@@ -71,36 +213,74 @@ func (d duration) MarshalJSON() ([]byte, error) {
 	return json.Marshal(int64(d.Duration.Seconds()))
 }
 
-func withDeadline(deadline time.Duration, f func() error) error {
-	c := make(chan error, 1)
-	defer close(c)
-	go func() {
-		c <- try.Do(func(attempt int) (bool, error) {
-			time.Sleep(time.Duration(attempt) * time.Millisecond)
-			err := f()
-			return attempt < 3, err
-		})
-	}()
-	select {
-	case err := <-c:
-		if err != nil {
-			log.WithError(err).Errorf("Call to f() failed with error, %s", err.Error())
-		}
-		return err
-	case <-time.After(deadline):
+func withDeadline(deadline time.Duration, f func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), deadline)
+	defer cancel()
+
+	err := f(ctx)
+	if errors.Is(err, aurora.ErrTimeout) {
 		log.WithField("deadline", deadline).Warning("Timeout while reading from inverter")
-		return errors.New("Timeout while waiting for operation to complete")
+	} else if err != nil {
+		log.WithError(err).Errorf("Call to f() failed with error, %s", err.Error())
+	}
+	return err
+}
+
+// capabilityCache keeps what each model of inverter supports in a file so that
+// they needn't be probed every time the monitor starts
+type capabilityCache struct {
+	aurora.CapabilityCache
+	path string
+	mu   sync.Mutex
+}
+
+func (c *capabilityCache) load() error {
+	data, err := ioutil.ReadFile(c.path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
 	}
+	return json.Unmarshal(data, &c.CapabilityCache)
+}
+
+func (c *capabilityCache) save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	data, err := json.MarshalIndent(&c.CapabilityCache, "", "\t")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(c.path, data, 0644)
 }
 
 type configStruct struct {
 	Name          string
+	URL           string // Overrides Comms, eg tcp://10.0.0.5:4001
 	Comms         serialConfig
 	UpdateRate    duration
 	Deadline      duration
 	UnitAddresses []byte
 }
 
+// port names the connection for logging and result keys
+func (c *configStruct) port() string {
+	if c.URL != "" {
+		return c.URL
+	}
+	return c.Comms.Name
+}
+
+// open dials the URL if there is one, otherwise opens the serial port. The port
+// is opened by the transport package so it supports deadlines, and no read may
+// block for longer than the deadline on platforms where it doesn't.
+func (c *configStruct) open(deadline time.Duration) (io.ReadWriteCloser, error) {
+	if c.URL != "" {
+		return transport.Dial(c.URL)
+	}
+	return transport.Dial(c.Comms.URL(deadline))
+}
+
 func main() {
 	dir, err := filepath.Abs(filepath.Dir(os.Args[0]))
 	if err != nil {
@@ -108,19 +288,26 @@ func main() {
 	}
 
 	config := struct {
-		LogPath    string
-		UpdateRate duration
-		Deadline   duration
-		Listen     string
-		Devices    []configStruct
+		LogPath       string
+		UpdateRate    duration
+		Deadline      duration
+		ProbeDeadline duration
+		Capabilities  string
+		Metrics       []string // Read as well as the usual values, see aurora.Metrics
+		Listen        string
+		Devices       []configStruct
 	}{
-		LogPath: filepath.Join(dir, "main.log"),
+		LogPath:      filepath.Join(dir, "main.log"),
+		Capabilities: filepath.Join(dir, "capabilities.json"),
 		UpdateRate: duration{
 			Duration: time.Minute,
 		},
 		Deadline: duration{
 			Duration: 5 * time.Second,
 		},
+		ProbeDeadline: duration{
+			Duration: 2 * time.Minute,
+		},
 		Listen: ":8080",
 	}
 
@@ -145,13 +332,23 @@ func main() {
 
 	log.WithField("config", config).Debug("Configuration")
 
+	metrics, err := lookupMetrics(config.Metrics)
+	if err != nil {
+		log.Fatalf("Unable to configure metrics: %v", err)
+	}
+
 	buffer := results{
 		Results: map[string]*result{},
 	}
 
+	cache := &capabilityCache{path: config.Capabilities}
+	if err := cache.load(); err != nil {
+		log.WithError(err).Warning("Unable to load inverter capabilities, they will be probed")
+	}
+
 	for _, device := range config.Devices {
 		go func(device configStruct) {
-			logger := log.WithField("coms", device.Comms.Name)
+			logger := log.WithField("coms", device.port())
 			deadline := device.Deadline.Duration
 			if deadline == 0 {
 				deadline = config.Deadline.Duration
@@ -162,44 +359,56 @@ func main() {
 				updateRate = config.UpdateRate.Duration
 			}
 
-			port, err := serial.OpenPort(device.Comms.Normalise())
+			port, err := device.open(deadline)
 			if err != nil {
-				log.WithError(err).Fatal("Startup error: Unable to open serial port")
+				log.WithError(err).Fatal("Startup error: Unable to open port")
 			}
 
-			inverter := &aurora.Inverter{
-				Conn: port,
-			}
+			bus := aurora.NewBus(port)
+			inverters := map[byte]*aurora.Inverter{}
+			capabilities := map[byte]*aurora.Capabilities{}
 
 			for _, address := range device.UnitAddresses {
 				logger := logger.WithField("address", address)
-				name := fmt.Sprintf("%s::%d", device.Comms.Name, address)
-				inverter.Address = address
+				name := fmt.Sprintf("%s::%d", device.port(), address)
+				inverter := bus.Inverter(address)
+				inverters[address] = inverter
 				buffer.Results[name] = &result{
 					Address: address,
 				}
 
-				err := withDeadline(deadline, func() (err error) {
-					buffer.Results[name].SerialNumber, err = inverter.SerialNumber()
+				err := withDeadline(deadline, func(ctx context.Context) (err error) {
+					buffer.Results[name].SerialNumber, err = inverter.SerialNumberContext(ctx)
 					return
 				})
 
 				if err != nil {
 					logger.WithError(err).Fatal("Startup error: Unable to communicate with inverter")
 				}
+
+				// Without capabilities everything is read, as it always was
+				err = withDeadline(config.ProbeDeadline.Duration, func(ctx context.Context) (err error) {
+					capabilities[address], err = cache.Capabilities(ctx, inverter)
+					return
+				})
+				if err != nil {
+					logger.WithError(err).Warning("Unable to probe inverter capabilities")
+				} else if err := cache.save(); err != nil {
+					logger.WithError(err).Warning("Unable to save inverter capabilities")
+				}
 			}
 
 			ticker := time.NewTicker(updateRate)
 			now := time.Now()
 			for {
 				for _, address := range device.UnitAddresses {
-					name := fmt.Sprintf("%s::%d", device.Comms.Name, address)
+					name := fmt.Sprintf("%s::%d", device.port(), address)
 					buffer.RLock()
 					logger := logger.WithFields(log.Fields{
 						"address": address,
 						"serial":  buffer.Results[name].SerialNumber,
 					})
-					inverter.Address = address
+					inverter := inverters[address]
 					r := &result{
 						Address:      address,
 						SerialNumber: buffer.Results[name].SerialNumber,
@@ -207,82 +416,23 @@ func main() {
 					}
 					buffer.RUnlock()
 
-					err := withDeadline(deadline, func() error {
-						var err error
-						if r.BoosterTemperature, err = inverter.BoosterTemperature(); err != nil {
-							logger.WithError(err).Warning("Unable to read BoosterTemperature")
-							return err
-						}
-						if r.InverterTemperature, err = inverter.InverterTemperature(); err != nil {
-							logger.WithError(err).Warning("Unable to read InverterTemperature")
-							return err
-						}
-						if r.Frequency, err = inverter.Frequency(); err != nil {
-							logger.WithError(err).Warning("Unable to read Frequency")
-							return err
-						}
-						if r.GridVoltage, err = inverter.GridVoltage(); err != nil {
-							logger.WithError(err).Warning("Unable to read GridVoltage")
-							return err
-						}
-						if r.GridCurrent, err = inverter.GridCurrent(); err != nil {
-							logger.WithError(err).Warning("Unable to read GridCurrent")
-							return err
-						}
-						if r.GridPower, err = inverter.GridPower(); err != nil {
-							logger.WithError(err).Warning("Unable to read GridPower")
-							return err
-						}
-						if r.GridRunTime.Duration, err = inverter.GridRunTime(); err != nil {
-							logger.WithError(err).Warning("Unable to read GridRunTime")
-							return err
-						}
-						if r.Input1Voltage, err = inverter.Input1Voltage(); err != nil {
-							logger.WithError(err).Warning("Unable to read Input1Voltage")
-							return err
-						}
-						if r.Input1Current, err = inverter.Input1Current(); err != nil {
-							logger.WithError(err).Warning("Unable to read Input1Current")
-							return err
-						}
-						if r.Input2Voltage, err = inverter.Input2Voltage(); err != nil {
-							logger.WithError(err).Warning("Unable to read Input2Voltage")
-							return err
-						}
-						if r.Input2Current, err = inverter.Input2Current(); err != nil {
-							logger.WithError(err).Warning("Unable to read Input2Current")
-							return err
-						}
-						if r.Joules, err = inverter.Joules(); err != nil {
-							logger.WithError(err).Warning("Unable to read Joules")
-							return err
-						}
-						if r.DailyEnergy, err = inverter.DailyEnergy(); err != nil {
-							logger.WithError(err).Warning("Unable to read DailyEnergy")
-							return err
-						}
-						if r.WeeklyEnergy, err = inverter.WeeklyEnergy(); err != nil {
-							logger.WithError(err).Warning("Unable to read WeeklyEnergy")
-							return err
-						}
-						if r.MonthlyEnergy, err = inverter.MonthlyEnergy(); err != nil {
-							logger.WithError(err).Warning("Unable to read MonthlyEnergy")
-							return err
-						}
-						if r.YearlyEnergy, err = inverter.YearlyEnergy(); err != nil {
-							logger.WithError(err).Warning("Unable to read YearlyEnergy")
-							return err
-						}
-						if r.TotalEnergy, err = inverter.TotalEnergy(); err != nil {
-							logger.WithError(err).Warning("Unable to read TotalEnergy")
-							return err
-						}
-						r.TotalRunTime.Duration, err = inverter.TotalRunTime()
-						if err != nil {
-							logger.WithError(err).Warning("Unable to read TotalRunTime")
-						}
-						return err
+					ctx, cancel := context.WithTimeout(context.Background(), deadline)
+					snapshot := inverter.Snapshot(ctx, &aurora.SnapshotOptions{
+						Capabilities: capabilities[address],
+						DSP:          dspParameters,
 					})
+					if snapshot.State.Err == nil {
+						r.read(ctx, inverter, metrics, capabilities[address], logger)
+					}
+					cancel()
+
+					err := snapshot.State.Err
+					if errors.Is(err, aurora.ErrTimeout) {
+						logger.WithField("deadline", deadline).Warning("Timeout while reading from inverter")
+					} else if err != nil {
+						logger.WithError(err).Warning("Unable to read State")
+					}
+					r.fill(snapshot, logger)
 
 					if err == nil {
 						buffer.Lock()
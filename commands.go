@@ -0,0 +1,237 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package aurora
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// Errors returned for misuse of a command, wrapped in an *ArgumentError
+var (
+	ErrUnknownCommand = errors.New("Unknown command")
+	ErrArgumentCount  = errors.New("Wrong number of arguments")
+	ErrArgumentType   = errors.New("Wrong type of argument")
+	ErrNotMeasurement = errors.New("Not a measurement") // Reading a metric whose command doesn't measure anything
+)
+
+// Layout is how the payload of the response to a command is laid out
+type Layout byte
+
+// Response layouts
+const (
+	LayoutData  Layout = iota // Transmission state, global state then 4 bytes of data
+	LayoutState               // Transmission state then 5 bytes of data starting with the global state
+	LayoutRaw                 // 6 bytes of data without a transmission state
+)
+
+// CommandSpec describes a command, how to call it and what it returns
+type CommandSpec struct {
+	Command Command
+	Name    string
+
+	// Args holds a value of the type of each argument the command takes
+	Args []Argument
+
+	// Layout is how the response is laid out
+	Layout Layout
+
+	// Writes is true if the command changes state in the inverter
+	Writes bool
+
+	// Decode returns the body of the response as a value, when nil the body is
+	// returned as is. args are those of the request, if known, so a DSP value
+	// can be given the unit of its parameter.
+	Decode func(body []byte, args []Argument) (interface{}, error)
+}
+
+// ArgumentError is returned for a command that is unknown or called with the
+// wrong arguments, it wraps ErrUnknownCommand, ErrArgumentCount or
+// ErrArgumentType. Reading a metric that isn't a measurement wraps ErrNotMeasurement.
+type ArgumentError struct {
+	Command Command
+	Index   int      // Index of the offending argument
+	Got     Argument // The offending argument
+	Want    Argument // A value of the type expected
+	Err     error
+}
+
+func (e *ArgumentError) Error() string {
+	switch e.Err {
+	case ErrArgumentCount, ErrNotMeasurement:
+		return fmt.Sprintf("%s: %v", e.Command, e.Err)
+	case ErrArgumentType:
+		return fmt.Sprintf("%s: %v %d, expected %T got %T", e.Command, e.Err, e.Index, e.Want, e.Got)
+	}
+	return fmt.Sprintf("%v %d", e.Err, byte(e.Command))
+}
+
+// Unwrap returns the underlying error
+func (e *ArgumentError) Unwrap() error {
+	return e.Err
+}
+
+var commandSpecs = map[Command]*CommandSpec{}
+
+func init() {
+	for _, spec := range []*CommandSpec{
+		{
+			Command: GetState,
+			Layout:  LayoutState,
+			Decode: func(body []byte, args []Argument) (interface{}, error) {
+				var state State
+				return &state, decodeVar(body, &state)
+			},
+		},
+		{
+			Command: GetPartNumber,
+			Layout:  LayoutRaw,
+			Decode:  decodeString,
+		},
+		{
+			Command: GetVersion,
+			Decode: func(body []byte, args []Argument) (interface{}, error) {
+				var version Version
+				return &version, decodeVar(body, &version)
+			},
+		},
+		{
+			Command: GetDSP,
+			Args:    []Argument{DSParameter(0)},
+			Decode: func(body []byte, args []Argument) (interface{}, error) {
+				var value float32
+				err := decodeVar(body, &value)
+				return Measurement{Value: float64(value), Unit: dspUnit(args)}, err
+			},
+		},
+		{
+			Command: GetSerialNumber,
+			Layout:  LayoutRaw,
+			Decode:  decodeString,
+		},
+		{
+			Command: GetManufacturingDate,
+			Decode:  decodeString,
+		},
+		{
+			Command: GetFlags,
+			Decode: func(body []byte, args []Argument) (interface{}, error) {
+				var flags Flags
+				return &flags, decodeVar(body, &flags)
+			},
+		},
+		{
+			Command: GetTime,
+			Decode: func(body []byte, args []Argument) (interface{}, error) {
+				return decodeTime(body), nil
+			},
+		},
+		{
+			Command: SetTime,
+			Args:    []Argument{Byte(0), Byte(0), Byte(0), Byte(0)},
+			Writes:  true,
+		},
+		{
+			Command: GetFirmwareVersion,
+			Decode: func(body []byte, args []Argument) (interface{}, error) {
+				return decodeFirmware(body), nil
+			},
+		},
+		{
+			Command: GetLast10SecEnergy,
+			Decode: func(body []byte, args []Argument) (interface{}, error) {
+				var value Joules
+				return value, decodeVar(body, &value)
+			},
+		},
+		{
+			Command: GetConfiguration,
+			Decode: func(body []byte, args []Argument) (interface{}, error) {
+				return ConfigurationState(body[0]), nil
+			},
+		},
+		{
+			Command: GetCumulatedEnergy,
+			Args:    []Argument{CumulationPeriod(0)},
+			Decode: func(body []byte, args []Argument) (interface{}, error) {
+				return WattHours(binary.BigEndian.Uint32(body)), nil
+			},
+		},
+		{
+			// Resetting the partial counter is done by reading CounterReset
+			Command: GetCounters,
+			Args:    []Argument{Counter(0)},
+			Decode: func(body []byte, args []Argument) (interface{}, error) {
+				return time.Duration(binary.BigEndian.Uint32(body)) * time.Second, nil
+			},
+		},
+		{
+			Command: GetLast4Alarms,
+			Decode: func(body []byte, args []Argument) (interface{}, error) {
+				alarms := make(AlarmStates, 4)
+				return alarms, decodeVar(body, alarms)
+			},
+		},
+	} {
+		spec.Name = spec.Command.String()
+		commandSpecs[spec.Command] = spec
+	}
+}
+
+// LookupCommand returns the description of a command
+func LookupCommand(command Command) (*CommandSpec, bool) {
+	spec, ok := commandSpecs[command]
+	return spec, ok
+}
+
+// Commands returns the descriptions of every known command in order
+func Commands() []*CommandSpec {
+	specs := make([]*CommandSpec, 0, len(commandSpecs))
+	for _, spec := range commandSpecs {
+		specs = append(specs, spec)
+	}
+	sort.Slice(specs, func(a, b int) bool { return specs[a].Command < specs[b].Command })
+	return specs
+}
+
+// Validate checks the arguments are those the command takes
+func (s *CommandSpec) Validate(args ...Argument) error {
+	if len(args) != len(s.Args) {
+		return &ArgumentError{Command: s.Command, Index: len(args), Err: ErrArgumentCount}
+	}
+	for index, arg := range args {
+		if reflect.TypeOf(arg) != reflect.TypeOf(s.Args[index]) {
+			return &ArgumentError{Command: s.Command, Index: index, Got: arg, Want: s.Args[index], Err: ErrArgumentType}
+		}
+	}
+	return nil
+}
+
+// validate checks the command is known and the arguments are those it takes
+func validate(command Command, args []Argument) (*CommandSpec, error) {
+	spec, ok := LookupCommand(command)
+	if !ok {
+		return nil, &ArgumentError{Command: command, Err: ErrUnknownCommand}
+	}
+	return spec, spec.Validate(args...)
+}
+
+func decodeString(body []byte, args []Argument) (interface{}, error) {
+	return string(body), nil
+}
+
+// dspUnit returns the unit of the DSParameter in args, UnitNone if there isn't one
+func dspUnit(args []Argument) UnitOfMeasure {
+	if len(args) > 0 {
+		if parameter, ok := args[0].(DSParameter); ok {
+			return parameter.Unit()
+		}
+	}
+	return UnitNone
+}
@@ -0,0 +1,93 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package aurora_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/freman/go-aurora"
+)
+
+func TestCommandValidation(t *testing.T) {
+	ttys0, _ := mockSerialPair()
+	i := &aurora.Inverter{Conn: ttys0, Address: 2}
+
+	// None of these should make it as far as the wire, which would block
+	tests := []struct {
+		Command aurora.Command
+		Args    []aurora.Argument
+		Expect  error
+	}{
+		{Command: aurora.Command(99), Expect: aurora.ErrUnknownCommand},
+		{Command: aurora.GetDSP, Expect: aurora.ErrArgumentCount},
+		{Command: aurora.GetVersion, Args: []aurora.Argument{aurora.Byte(1)}, Expect: aurora.ErrArgumentCount},
+		{Command: aurora.GetDSP, Args: []aurora.Argument{aurora.CumulatedDaily}, Expect: aurora.ErrArgumentType},
+		{Command: aurora.GetCumulatedEnergy, Args: []aurora.Argument{aurora.CounterTotal}, Expect: aurora.ErrArgumentType},
+		{Command: aurora.SetTime, Args: []aurora.Argument{aurora.Byte(1), aurora.Byte(2), aurora.Byte(3)}, Expect: aurora.ErrArgumentCount},
+	}
+
+	for _, test := range tests {
+		_, err := i.Communicate(test.Command, test.Args...)
+		if !errors.Is(err, test.Expect) {
+			t.Errorf("%s: expected %v got %v", test.Command, test.Expect, err)
+		}
+
+		var argErr *aurora.ArgumentError
+		if !errors.As(err, &argErr) || argErr.Command != test.Command {
+			t.Errorf("%s: expected *ArgumentError got %#v", test.Command, err)
+		}
+	}
+
+	if stats := i.Stats(); stats.Requests != 0 {
+		t.Errorf("Expected %d requests got %d", 0, stats.Requests)
+	}
+}
+
+func TestArgumentErrorString(t *testing.T) {
+	spec, _ := aurora.LookupCommand(aurora.GetDSP)
+	err := spec.Validate(aurora.CumulatedDaily)
+	if str := err.Error(); str != "Measure Request to the DSP: Wrong type of argument 0, expected aurora.DSParameter got aurora.CumulationPeriod" {
+		t.Errorf("Unexpected string: %s", str)
+	}
+}
+
+func TestCommands(t *testing.T) {
+	specs := aurora.Commands()
+	if len(specs) == 0 {
+		t.Fatal("Expected commands")
+	}
+
+	for n, spec := range specs {
+		if n > 0 && specs[n-1].Command >= spec.Command {
+			t.Errorf("Commands out of order at %d", n)
+		}
+		if spec.Name == "" {
+			t.Errorf("Command %d has no name", byte(spec.Command))
+		}
+		if spec.Command.String() != spec.Name {
+			t.Errorf("Expected %s got %s", spec.Name, spec.Command)
+		}
+	}
+
+	if spec, ok := aurora.LookupCommand(aurora.SetTime); !ok || !spec.Writes {
+		t.Errorf("Expected %s to write", aurora.SetTime)
+	}
+}
+
+func TestQuery(t *testing.T) {
+	ttys0, ttys1 := mockSerialPair()
+	i := &aurora.Inverter{Conn: ttys0, Address: 2}
+
+	go mockRespond(t, ttys1, []byte{0, 6, 0, 0, 0x30, 0x39}, false)
+
+	value, err := i.Query(aurora.GetCumulatedEnergy, aurora.CumulatedDaily)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != aurora.WattHours(12345) {
+		t.Errorf("Expected %d got %v", 12345, value)
+	}
+}
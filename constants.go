@@ -2,119 +2,93 @@
 // Use of this source code is governed by a MIT
 // license that can be found in the LICENSE file.
 
-package aurora
+// Code generated by protogen from protocol.json. DO NOT EDIT.
 
-// InverterEpochOffset is the number of seconds since unix epoch that
-// Power-One started the Aurora firmware epoch
-const InverterEpochOffset = 946706400
+package aurora
 
 // Command values
 const (
-	GetState Command = 50 + iota // Get the inverter state
-	_
-	GetPartNumber // Get the inverters part number
-	_
-	_
-	_
-	_
-	_
-	GetVersion // Get the hardware build version
-	GetDSP     // Get a value from the DSP
-	_
-	_
-	_
-	GetSerialNumber // Get the inverters serial number
-	_
-	GetManufacturingDate // Get the year and month of manufacture
-	_
-	_
-	_
-	_
-	GetTime            // Get the time from the inverter
-	SetTime            // Set the time for the inverter
-	GetFirmwareVersion // Get the inverters firmware version
-	_
-	_
-	_
-	GetLast10SecEnergy // Get the amount of energy exported in the past 10 seconds
-	GetConfiguration   // Get the inverter configuration
-	GetCumulatedEnergy // Get a value from the cumulated energy table
-	_
-	GetCounters // Get a counter
-	_
-	_
-	_
-	_
-	_
-	GetLast4Alarms // Get the last 4 alarms
+	GetState             Command = 50 // Get the inverter state
+	GetPartNumber        Command = 52 // Get the inverters part number
+	GetVersion           Command = 58 // Get the hardware build version
+	GetDSP               Command = 59 // Get a value from the DSP
+	GetSerialNumber      Command = 63 // Get the inverters serial number
+	GetManufacturingDate Command = 65 // Get the year and month of manufacture
+	GetFlags             Command = 67 // Get the flags and switches
+	GetTime              Command = 70 // Get the time from the inverter
+	SetTime              Command = 71 // Set the time for the inverter
+	GetFirmwareVersion   Command = 72 // Get the inverters firmware version
+	GetLast10SecEnergy   Command = 76 // Get the amount of energy exported in the past 10 seconds
+	GetConfiguration     Command = 77 // Get the inverter configuration
+	GetCumulatedEnergy   Command = 78 // Get a value from the cumulated energy table
+	GetCounters          Command = 80 // Get a counter
+	GetLast4Alarms       Command = 86 // Get the last 4 alarms
 )
 
 // Available cumulation values
 const (
-	CumulatedDaily CumulationPeriod = iota
-	CumulatedWeekly
-	_
-	CumulatedMonthly
-	CumulatedYearly
-	CumulatedTotal
-	CumulatedPartial
+	CumulatedDaily     CumulationPeriod = 0
+	CumulatedWeekly    CumulationPeriod = 1
+	CumulatedLast7Days CumulationPeriod = 2
+	CumulatedMonthly   CumulationPeriod = 3
+	CumulatedYearly    CumulationPeriod = 4
+	CumulatedTotal     CumulationPeriod = 5
+	CumulatedPartial   CumulationPeriod = 6
 )
 
 // Available DSP values
 const (
-	DSPGridVoltage DSParameter = (1 + iota)
-	DSPGridCurrent
-	DSPGridPower
-	DSPFrequency
-	DSPVbulk
-	DSPIleakDCDC
-	DSPIleakInverter
-	DSPPin1
-	DSPPin2
-	_ DSParameter = (11 + iota)
-	DSPInverterTemperature
-	DSPBoosterTemperature
-	DSPInput1Voltage
-	_
-	DSPInput1Current
-	DSPInput2Voltage
-	DSPInput2Current
-	DSPGridVoltageDCDC
-	DSPGridFrequencyDCDC
-	DSPIsolationResistance
-	DSPVbulkDCDC
-	DSPAverageGridVoltage
-	DSPVbulkMid
-	DSPPowerPeak
-	DSPPowerPeakToday
-	DSPGridVoltageNeutral
-	DSPWindGeneratorFrequency
-	DSPGridVoltageNeutralPhase
-	DSPGridCurrentPhaseR
-	DSPGridCurrentPhaseS
-	DSPGridCurrentPhaseT
-	DSPFrequencyPhaseR
-	DSPFrequencyPhaseS
-	DSPFrequencyPhaseT
-	DSPVbulkPositive
-	DSPVbulkNegative
-	DSPSupervisorTemperature
-	DSPAlimTemperature
-	DSPHeatSinkTemperature
-	DSPTemperature1
-	DSPTemperature2
-	DSPTemperature3
-	DSPFan1Speed
-	DSPFan2Speed
-	DSPFan3Speed
-	DSPFan4Speed
-	DSPFan5Speed
-	DSPPowerSaturationLimit
-	DSPRiferimentoAnelloBulk
-	DSPVpanelMicro
-	DSPGridVoltagePhaseR
-	DSPGridVoltagePhaseS
-	DSPGridVoltagePhaseT
+	DSPGridVoltage             DSParameter = 1
+	DSPGridCurrent             DSParameter = 2
+	DSPGridPower               DSParameter = 3
+	DSPFrequency               DSParameter = 4
+	DSPVbulk                   DSParameter = 5
+	DSPIleakDCDC               DSParameter = 6
+	DSPIleakInverter           DSParameter = 7
+	DSPPin1                    DSParameter = 8
+	DSPPin2                    DSParameter = 9
+	DSPInverterTemperature     DSParameter = 21
+	DSPBoosterTemperature      DSParameter = 22
+	DSPInput1Voltage           DSParameter = 23
+	DSPInput1Current           DSParameter = 25
+	DSPInput2Voltage           DSParameter = 26
+	DSPInput2Current           DSParameter = 27
+	DSPGridVoltageDCDC         DSParameter = 28
+	DSPGridFrequencyDCDC       DSParameter = 29
+	DSPIsolationResistance     DSParameter = 30
+	DSPVbulkDCDC               DSParameter = 31
+	DSPAverageGridVoltage      DSParameter = 32
+	DSPVbulkMid                DSParameter = 33
+	DSPPowerPeak               DSParameter = 34
+	DSPPowerPeakToday          DSParameter = 35
+	DSPGridVoltageNeutral      DSParameter = 36
+	DSPWindGeneratorFrequency  DSParameter = 37
+	DSPGridVoltageNeutralPhase DSParameter = 38
+	DSPGridCurrentPhaseR       DSParameter = 39
+	DSPGridCurrentPhaseS       DSParameter = 40
+	DSPGridCurrentPhaseT       DSParameter = 41
+	DSPFrequencyPhaseR         DSParameter = 42
+	DSPFrequencyPhaseS         DSParameter = 43
+	DSPFrequencyPhaseT         DSParameter = 44
+	DSPVbulkPositive           DSParameter = 45
+	DSPVbulkNegative           DSParameter = 46
+	DSPSupervisorTemperature   DSParameter = 47
+	DSPAlimTemperature         DSParameter = 48
+	DSPHeatSinkTemperature     DSParameter = 49
+	DSPTemperature1            DSParameter = 50
+	DSPTemperature2            DSParameter = 51
+	DSPTemperature3            DSParameter = 52
+	DSPFan1Speed               DSParameter = 53
+	DSPFan2Speed               DSParameter = 54
+	DSPFan3Speed               DSParameter = 55
+	DSPFan4Speed               DSParameter = 56
+	DSPFan5Speed               DSParameter = 57
+	DSPPowerSaturationLimit    DSParameter = 58
+	DSPRiferimentoAnelloBulk   DSParameter = 59
+	DSPVpanelMicro             DSParameter = 60
+	DSPGridVoltagePhaseR       DSParameter = 61
+	DSPGridVoltagePhaseS       DSParameter = 62
+	DSPGridVoltagePhaseT       DSParameter = 63
 )
 
 // Known products/models
@@ -149,212 +123,208 @@ const (
 // Transmission states
 const (
 	TSOk                    TransmissionState = 0
-	TSCommandNotImplemented TransmissionState = (51 + iota)
-	TSVariableDoesNotExist
-	TSValueOutOfRange
-	TSEEpromNotAccessible
-	TSMicroError
-	TSNotExecuted
-	TSVariableNotAvailable
+	TSCommandNotImplemented TransmissionState = 51
+	TSVariableDoesNotExist  TransmissionState = 52
+	TSValueOutOfRange       TransmissionState = 53
+	TSEEpromNotAccessible   TransmissionState = 54
+	TSNotToggledServiceMode TransmissionState = 55
+	TSMicroError            TransmissionState = 56
+	TSNotExecuted           TransmissionState = 57
+	TSVariableNotAvailable  TransmissionState = 58
 )
 
 // Global states
 const (
-	GSSendingParameters GlobalState = iota
-	GSWaitingSunGrid
-	GSCheckingGrid
-	GSMeasuringRiso
-	GSDCDCStart
-	GSInverterTurnOn
-	GSRun
-	GSRecovery
-	GSPause
-	GSGroundFault
-	GSOTHFault
-	GSAddressSetting
-	GSSelfTest
-	GSSelfTestFail
-	GSSensorTestMeasureRiso
-	GSLeakFault
-	GSWaitingManualReset
-	GSInternalErrorE026
-	GSInternalErrorE027
-	GSInternalErrorE028
-	GSInternalErrorE029
-	GSInternalErrorE030
-	GSSendingWindTable
-	GSFailedSendingTable
-	GSUTHFault
-	GSRemoteOff
-	GSInterlockFail
-	GSExecutingAutotest
-	_
-	_
-	GSWaitingSun
-	GSTemperatureFault
-	GSFanStaucked
-	GSIntComFail
-	GSSlaveInsertion
-	GSDCSwitchOpen
-	GSTrasSwitchOpen
-	GSMasterExclusion
-	GSAutoExclusion
-	_ GlobalState = iota + 58
-	GSErasingInternalEEprom
-	GSErasingExternalEEprom
-	GSCountingEEprom
-	GSFreeze
+	GSSendingParameters     GlobalState = 0
+	GSWaitingSunGrid        GlobalState = 1
+	GSCheckingGrid          GlobalState = 2
+	GSMeasuringRiso         GlobalState = 3
+	GSDCDCStart             GlobalState = 4
+	GSInverterTurnOn        GlobalState = 5
+	GSRun                   GlobalState = 6
+	GSRecovery              GlobalState = 7
+	GSPause                 GlobalState = 8
+	GSGroundFault           GlobalState = 9
+	GSOTHFault              GlobalState = 10
+	GSAddressSetting        GlobalState = 11
+	GSSelfTest              GlobalState = 12
+	GSSelfTestFail          GlobalState = 13
+	GSSensorTestMeasureRiso GlobalState = 14
+	GSLeakFault             GlobalState = 15
+	GSWaitingManualReset    GlobalState = 16
+	GSInternalErrorE026     GlobalState = 17
+	GSInternalErrorE027     GlobalState = 18
+	GSInternalErrorE028     GlobalState = 19
+	GSInternalErrorE029     GlobalState = 20
+	GSInternalErrorE030     GlobalState = 21
+	GSSendingWindTable      GlobalState = 22
+	GSFailedSendingTable    GlobalState = 23
+	GSUTHFault              GlobalState = 24
+	GSRemoteOff             GlobalState = 25
+	GSInterlockFail         GlobalState = 26
+	GSExecutingAutotest     GlobalState = 27
+	GSWaitingSun            GlobalState = 30
+	GSTemperatureFault      GlobalState = 31
+	GSFanStaucked           GlobalState = 32
+	GSIntComFail            GlobalState = 33
+	GSSlaveInsertion        GlobalState = 34
+	GSDCSwitchOpen          GlobalState = 35
+	GSTrasSwitchOpen        GlobalState = 36
+	GSMasterExclusion       GlobalState = 37
+	GSAutoExclusion         GlobalState = 38
+	GSErasingInternalEEprom GlobalState = 98
+	GSErasingExternalEEprom GlobalState = 99
+	GSCountingEEprom        GlobalState = 100
+	GSFreeze                GlobalState = 101
 )
 
 // Inverter states
 const (
-	ISStandBy InverterState = iota
-	ISCheckingGrid
-	ISRun
-	ISBulkOverVoltage
-	ISOutOverCurrent
-	ISIGBTSat
-	ISBulkUnderVoltage
-	ISDegaussError
-	ISNoParameters
-	ISBulkLow
-	ISGridOverVoltage
-	ISCommunicationError
-	ISDegaussing
-	ISStarting
-	ISBulkCapFail
-	ISLeakFail
-	ISDCDCFail
-	ISIleakSensorFail
-	ISSelfTestRelayInverter
-	ISSelfTestWaitSensorTest
-	ISSelfTestTestRelayDCDCSensor
-	ISSelfTestRelayInverterFail
-	ISSelfTestTimeoutFail
-	ISSelfTestRelayDCDCFail
-	ISSelfTest1
-	ISWaitingSelfTestStart
-	ISDCInjection
-	ISSelfTest2
-	ISSelfTest3
-	ISSelfTest4
-	ISInternalError30
-	ISInternalError31
-	_ InverterState = iota + 7
-	ISForbiddenState
-	ISInputUC
-	ISZeroPower
-	ISGridNotPresent
-	ISWaitingStart
-	ISMPPT
-	ISGRIDFAIL
-	ISINPUTOC
+	ISStandBy                     InverterState = 0
+	ISCheckingGrid                InverterState = 1
+	ISRun                         InverterState = 2
+	ISBulkOverVoltage             InverterState = 3
+	ISOutOverCurrent              InverterState = 4
+	ISIGBTSat                     InverterState = 5
+	ISBulkUnderVoltage            InverterState = 6
+	ISDegaussError                InverterState = 7
+	ISNoParameters                InverterState = 8
+	ISBulkLow                     InverterState = 9
+	ISGridOverVoltage             InverterState = 10
+	ISCommunicationError          InverterState = 11
+	ISDegaussing                  InverterState = 12
+	ISStarting                    InverterState = 13
+	ISBulkCapFail                 InverterState = 14
+	ISLeakFail                    InverterState = 15
+	ISDCDCFail                    InverterState = 16
+	ISIleakSensorFail             InverterState = 17
+	ISSelfTestRelayInverter       InverterState = 18
+	ISSelfTestWaitSensorTest      InverterState = 19
+	ISSelfTestTestRelayDCDCSensor InverterState = 20
+	ISSelfTestRelayInverterFail   InverterState = 21
+	ISSelfTestTimeoutFail         InverterState = 22
+	ISSelfTestRelayDCDCFail       InverterState = 23
+	ISSelfTest1                   InverterState = 24
+	ISWaitingSelfTestStart        InverterState = 25
+	ISDCInjection                 InverterState = 26
+	ISSelfTest2                   InverterState = 27
+	ISSelfTest3                   InverterState = 28
+	ISSelfTest4                   InverterState = 29
+	ISInternalError30             InverterState = 30
+	ISInternalError31             InverterState = 31
+	ISForbiddenState              InverterState = 40
+	ISInputUC                     InverterState = 41
+	ISZeroPower                   InverterState = 42
+	ISGridNotPresent              InverterState = 43
+	ISWaitingStart                InverterState = 44
+	ISMPPT                        InverterState = 45
+	ISGRIDFAIL                    InverterState = 46
+	ISINPUTOC                     InverterState = 47
 )
 
 // DCDC states
 const (
-	DCDCOff DCDCState = iota
-	DCDCRampStart
-	DCDCMPPT
-	_
-	DCDCInputOverCurrent
-	DCDCInputUnderVoltage
-	DCDCInputOverVoltage
-	DCDCInputLow
-	DCDCNoParameters
-	DCDCBulkOverVoltage
-	DCDCCommunicationError
-	DCDCRampFail
-	DCDCInternalError
-	DCDCInputModeError
-	DCDCGroundFault
-	DCDCInverterFail
-	DCDCIGBTSat
-	DCDCILEAKFail
-	DCDCGridFail
-	DCDCCommError
+	DCDCOff                DCDCState = 0
+	DCDCRampStart          DCDCState = 1
+	DCDCMPPT               DCDCState = 2
+	DCDCInputOverCurrent   DCDCState = 4
+	DCDCInputUnderVoltage  DCDCState = 5
+	DCDCInputOverVoltage   DCDCState = 6
+	DCDCInputLow           DCDCState = 7
+	DCDCNoParameters       DCDCState = 8
+	DCDCBulkOverVoltage    DCDCState = 9
+	DCDCCommunicationError DCDCState = 10
+	DCDCRampFail           DCDCState = 11
+	DCDCInternalError      DCDCState = 12
+	DCDCInputModeError     DCDCState = 13
+	DCDCGroundFault        DCDCState = 14
+	DCDCInverterFail       DCDCState = 15
+	DCDCIGBTSat            DCDCState = 16
+	DCDCILEAKFail          DCDCState = 17
+	DCDCGridFail           DCDCState = 18
+	DCDCCommError          DCDCState = 19
 )
 
 // Alarm states
 const (
-	AlarmNone AlarmState = iota
-	AlarmSunLow1
-	AlarmInputOverCurrent
-	AlarmInputUnderVoltage
-	AlarmInputOverVoltage
-	AlarmSunLow5
-	AlarmNoParameters
-	AlarmBulkOverVoltage
-	AlarmCommError
-	AlarmOutputOverCurrent
-	AlarmIGBTSat
-	AlarmBulkUV11
-	AlarmE009
-	AlarmGridFail
-	AlarmBulkLow
-	AlarmRampFail
-	AlarmDCDCFail16
-	AlarmWrongMode
-	AlarmGroundFault18
-	AlarmOverTemp
-	AlarmBulkCapFail
-	AlarmInverterFail
-	AlarmStartTimeout
-	AlarmGroundFault23
-	AlarmDegaussError
-	AlarmIleakSensFail
-	AlarmDCDCFail25
-	AlarmSelfTestError1
-	AlarmSelfTestError2
-	AlarmSelfTestError3
-	AlarmSelfTestError4
-	AlarmDCInjError
-	AlarmGridOverVoltage
-	AlarmGridUnderVoltage
-	AlarmGridOF
-	AlarmGridUF
-	AlarmZGridHi
-	AlarmE024
-	AlarmRisoLow
-	ALarmVrefError
-	AlarmErrorMeasV
-	AlarmErrorMeasF
-	AlarmErrorMeasI
-	AlarmErrorMeasIleak
-	AlarmReadErrorV
-	AlarmReadErrorI
-	AlarmTableFail
-	AlarmFanFail
-	AlarmUTH
-	AlarmInterlockFail
-	AlarmRemoteOff
-	AlarmVoutAvgError
-	AlarmBatteryLow
-	AlarmClkFail
-	AlarmInputUC
-	AlarmZeroPower
-	AlarmFanStucked
-	AlarmDCSwitchOpen
-	AlarmBulkUV58
-	AlarmAutoexclusion
-	AlarmGridDFDT
-	AlarmDenSwitchOpen
-	AlarmJboxFail
+	AlarmNone              AlarmState = 0
+	AlarmSunLow1           AlarmState = 1
+	AlarmInputOverCurrent  AlarmState = 2
+	AlarmInputUnderVoltage AlarmState = 3
+	AlarmInputOverVoltage  AlarmState = 4
+	AlarmSunLow5           AlarmState = 5
+	AlarmNoParameters      AlarmState = 6
+	AlarmBulkOverVoltage   AlarmState = 7
+	AlarmCommError         AlarmState = 8
+	AlarmOutputOverCurrent AlarmState = 9
+	AlarmIGBTSat           AlarmState = 10
+	AlarmBulkUV11          AlarmState = 11
+	AlarmE009              AlarmState = 12
+	AlarmGridFail          AlarmState = 13
+	AlarmBulkLow           AlarmState = 14
+	AlarmRampFail          AlarmState = 15
+	AlarmDCDCFail16        AlarmState = 16
+	AlarmWrongMode         AlarmState = 17
+	AlarmGroundFault18     AlarmState = 18
+	AlarmOverTemp          AlarmState = 19
+	AlarmBulkCapFail       AlarmState = 20
+	AlarmInverterFail      AlarmState = 21
+	AlarmStartTimeout      AlarmState = 22
+	AlarmGroundFault23     AlarmState = 23
+	AlarmDegaussError      AlarmState = 24
+	AlarmIleakSensFail     AlarmState = 25
+	AlarmDCDCFail25        AlarmState = 26
+	AlarmSelfTestError1    AlarmState = 27
+	AlarmSelfTestError2    AlarmState = 28
+	AlarmSelfTestError3    AlarmState = 29
+	AlarmSelfTestError4    AlarmState = 30
+	AlarmDCInjError        AlarmState = 31
+	AlarmGridOverVoltage   AlarmState = 32
+	AlarmGridUnderVoltage  AlarmState = 33
+	AlarmGridOF            AlarmState = 34
+	AlarmGridUF            AlarmState = 35
+	AlarmZGridHi           AlarmState = 36
+	AlarmE024              AlarmState = 37
+	AlarmRisoLow           AlarmState = 38
+	AlarmVrefError         AlarmState = 39
+	AlarmErrorMeasV        AlarmState = 40
+	AlarmErrorMeasF        AlarmState = 41
+	AlarmErrorMeasI        AlarmState = 42
+	AlarmErrorMeasIleak    AlarmState = 43
+	AlarmReadErrorV        AlarmState = 44
+	AlarmReadErrorI        AlarmState = 45
+	AlarmTableFail         AlarmState = 46
+	AlarmFanFail           AlarmState = 47
+	AlarmUTH               AlarmState = 48
+	AlarmInterlockFail     AlarmState = 49
+	AlarmRemoteOff         AlarmState = 50
+	AlarmVoutAvgError      AlarmState = 51
+	AlarmBatteryLow        AlarmState = 52
+	AlarmClkFail           AlarmState = 53
+	AlarmInputUC           AlarmState = 54
+	AlarmZeroPower         AlarmState = 55
+	AlarmFanStucked        AlarmState = 56
+	AlarmDCSwitchOpen      AlarmState = 57
+	AlarmBulkUV58          AlarmState = 58
+	AlarmAutoexclusion     AlarmState = 59
+	AlarmGridDFDT          AlarmState = 60
+	AlarmDenSwitchOpen     AlarmState = 61
+	AlarmJboxFail          AlarmState = 62
 )
 
 // Configuration states
 const (
-	ConfigBoth ConfigurationState = iota
-	ConfigString1
-	ConfigString2
+	ConfigBoth    ConfigurationState = 0
+	ConfigString1 ConfigurationState = 1
+	ConfigString2 ConfigurationState = 2
 )
 
 // Counter values
 const (
-	CounterTotal Counter = iota
-	CounterPartial
-	CounterGrid
-	CounterReset
+	CounterTotal   Counter = 0
+	CounterPartial Counter = 1
+	CounterGrid    Counter = 2
+	CounterReset   Counter = 3
 )
 
 // Inverter types
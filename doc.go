@@ -6,3 +6,5 @@
 Package aurora provides an interface for communicating with Power-One Aurora inverters.
 */
 package aurora
+
+//go:generate go run ./internal/protogen
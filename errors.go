@@ -0,0 +1,128 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package aurora
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+)
+
+// ErrCRCFailure is returned whenever the data read in from the serial port might
+// have been corrupted en route and no longer matches the crc
+var ErrCRCFailure = errors.New("CRC Failure")
+
+// ErrTimeout is returned when the inverter fails to respond before the deadline
+// of the context expires, or the connection itself reports a timeout
+var ErrTimeout = errors.New("Timeout while communicating with inverter")
+
+// ErrShortRead is returned when the connection is closed part way through
+// receiving a response from the inverter
+var ErrShortRead = errors.New("Short read from inverter")
+
+// ErrEchoMismatch is returned when the echo of the request expected from the
+// adapter is missing or doesn't match what was transmitted
+var ErrEchoMismatch = errors.New("Echo does not match request")
+
+// ErrNoDeadline is returned by Scan when the connection doesn't support read
+// deadlines, without them waiting on an empty address would never time out
+var ErrNoDeadline = errors.New("Connection does not support read deadlines")
+
+// InverterError is returned by Communicate and friends whenever an exchange with
+// an inverter fails. Err holds the cause, which is one of the sentinel errors
+// above, a TransmissionState reported by the inverter, a context error or the
+// error from the connection itself, so the likes of
+//
+//	errors.Is(err, aurora.ErrCRCFailure)
+//	errors.Is(err, aurora.TSVariableDoesNotExist)
+//
+// can be used to tell them apart.
+type InverterError struct {
+	Address  byte
+	Command  Command
+	State    TransmissionState // State reported by the inverter, TSOk if it didn't get that far
+	Frame    []byte            // Frame received from the inverter, if any
+	Attempts int               // Number of attempts made before giving up
+	Err      error
+}
+
+func (e *InverterError) Error() string {
+	return fmt.Sprintf("inverter %d: %s: %v", e.Address, e.Command, e.Err)
+}
+
+// Unwrap returns the cause of the error
+func (e *InverterError) Unwrap() error {
+	return e.Err
+}
+
+// Temporary returns true if the cause of the error is likely to go away should
+// the exchange be retried
+func (e *InverterError) Temporary() bool {
+	return Temporary(e.Err)
+}
+
+// Error implements error so that TransmissionStates may be matched with errors.Is
+func (t TransmissionState) Error() string {
+	return t.String()
+}
+
+// Temporary returns true for the transmission states reported by an inverter that
+// is busy rather than one that can never satisfy the request
+func (t TransmissionState) Temporary() bool {
+	return t == TSVariableNotAvailable || t == TSMicroError
+}
+
+// Temporary returns true if err is likely to go away should the exchange with
+// the inverter be retried, as is the case for line noise, timeouts and busy
+// inverters. Unimplemented commands, unknown variables and cancellation are not.
+func Temporary(err error) bool {
+	if errors.Is(err, ErrCRCFailure) || errors.Is(err, ErrTimeout) || errors.Is(err, ErrShortRead) || errors.Is(err, ErrEchoMismatch) {
+		return true
+	}
+
+	var state TransmissionState
+	if errors.As(err, &state) {
+		return state.Temporary()
+	}
+
+	return false
+}
+
+// newError wraps the given error as an *InverterError, mapping errors caused by
+// an expired context or connection deadline to ErrTimeout, those caused by
+// cancellation to the context error, and incomplete reads to ErrShortRead
+func (i *Inverter) newError(ctx context.Context, command Command, err error) *InverterError {
+	if ierr, ok := err.(*InverterError); ok {
+		return ierr
+	}
+
+	return &InverterError{
+		Address: i.Address,
+		Command: command,
+		Err:     transportError(ctx, err),
+	}
+}
+
+func transportError(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		if ctxErr == context.DeadlineExceeded {
+			return ErrTimeout
+		}
+		return ctxErr
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return ErrTimeout
+	}
+
+	if err == io.ErrUnexpectedEOF {
+		return ErrShortRead
+	}
+
+	return err
+}
@@ -0,0 +1,258 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package aurora
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// Frame sizes on the wire, including the CRC
+const (
+	RequestFrameSize  = 10
+	ResponseFrameSize = 8
+)
+
+// RequestFrame is a request sent to an inverter, 8 bytes followed by their CRC
+type RequestFrame struct {
+	Address byte
+	Command Command
+
+	// Args holds the arguments as transmitted, terminated by a 0 if there are
+	// fewer than 6 and padded with spaces
+	Args [6]byte
+}
+
+// ResponseFrame is the response from an inverter, 6 bytes followed by their CRC.
+// The payload is usually the transmission state and global state followed by
+// 4 bytes of data, but see Body.
+type ResponseFrame struct {
+	Payload [6]byte
+}
+
+// NewRequestFrame returns the frame requesting the command of the inverter at
+// the given address, any more than 6 arguments are ignored
+func NewRequestFrame(address byte, command Command, args ...Argument) *RequestFrame {
+	f := &RequestFrame{
+		Address: address,
+		Command: command,
+		Args:    [6]byte{32, 32, 32, 32, 32, 32},
+	}
+
+	last := -1
+	for index, arg := range args {
+		if index >= len(f.Args) {
+			break
+		}
+		f.Args[index] = arg.Byte()
+		last = index
+	}
+
+	// Inverter expects 0 terminated instructions
+	if last < len(f.Args)-1 {
+		f.Args[last+1] = 0
+	}
+
+	return f
+}
+
+// CRC returns the checksum the Aurora protocol appends to frames, a CRC-16 with
+// the polynomial 0x8408, inverted and transmitted little endian
+func CRC(data []byte) uint16 {
+	crc := uint16(0xffff)
+	for _, chr := range data {
+		for i, data := 0, chr; i < 8; i, data = i+1, data>>1 {
+			if (crc&0x0001)^uint16(data&0x01) == 1 {
+				crc = (crc >> 1) ^ 0x8408
+			} else {
+				crc = crc >> 1
+			}
+		}
+	}
+
+	return ^crc
+}
+
+// appendCRC returns the payload followed by its CRC
+func appendCRC(payload []byte) []byte {
+	frame := make([]byte, len(payload), len(payload)+2)
+	copy(frame, payload)
+	crc := CRC(payload)
+	return append(frame, byte(crc), byte(crc>>8))
+}
+
+// checkFrame returns the payload of a frame of the given size, failing with
+// ErrShortRead if it is too short or ErrCRCFailure if the CRC doesn't match
+func checkFrame(data []byte, size int) ([]byte, error) {
+	if len(data) < size {
+		return nil, ErrShortRead
+	}
+	payload := data[:size-2]
+	if CRC(payload) != binary.LittleEndian.Uint16(data[size-2:size]) {
+		return payload, ErrCRCFailure
+	}
+	return payload, nil
+}
+
+// payload returns the frame without its CRC
+func (f *RequestFrame) payload() []byte {
+	return append([]byte{f.Address, byte(f.Command)}, f.Args[:]...)
+}
+
+// CRC returns the checksum of the frame
+func (f *RequestFrame) CRC() uint16 {
+	return CRC(f.payload())
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (f *RequestFrame) MarshalBinary() ([]byte, error) {
+	return appendCRC(f.payload()), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, failing with
+// ErrShortRead or ErrCRCFailure should the data not be a valid frame
+func (f *RequestFrame) UnmarshalBinary(data []byte) error {
+	payload, err := checkFrame(data, RequestFrameSize)
+	if err != nil {
+		return err
+	}
+	f.Address = payload[0]
+	f.Command = Command(payload[1])
+	copy(f.Args[:], payload[2:])
+	return nil
+}
+
+func (f *RequestFrame) String() string {
+	return fmt.Sprintf("% X (%d)", f.payload(), f.CRC())
+}
+
+// CRC returns the checksum of the frame
+func (f *ResponseFrame) CRC() uint16 {
+	return CRC(f.Payload[:])
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (f *ResponseFrame) MarshalBinary() ([]byte, error) {
+	return appendCRC(f.Payload[:]), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, failing with
+// ErrShortRead should the data be too short. Should the CRC not match the
+// payload is still decoded but ErrCRCFailure is returned.
+func (f *ResponseFrame) UnmarshalBinary(data []byte) error {
+	payload, err := checkFrame(data, ResponseFrameSize)
+	if payload != nil {
+		copy(f.Payload[:], payload)
+	}
+	return err
+}
+
+// printable returns true if every byte is printable ASCII
+func printable(data []byte) bool {
+	for _, b := range data {
+		if b < ' ' || b > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// State returns the transmission state of the response
+func (f *ResponseFrame) State() TransmissionState {
+	return TransmissionState(f.Payload[0])
+}
+
+// Global returns the global state of the inverter sent with the response
+func (f *ResponseFrame) Global() GlobalState {
+	return GlobalState(f.Payload[1])
+}
+
+// Body returns the data in the response to the given command, laid out as per
+// the description of the command, failing with the transmission state should
+// it not be TSOk. A response without a transmission state only fails when it
+// can't be text, as when an inverter doesn't know its part number.
+func (f *ResponseFrame) Body(command Command) ([]byte, error) {
+	layout := LayoutData
+	if spec, ok := LookupCommand(command); ok {
+		layout = spec.Layout
+	}
+
+	if layout == LayoutRaw {
+		if state := f.State(); state != TSOk && state.Known() && !printable(f.Payload[1:]) {
+			return nil, state
+		}
+		return f.Payload[:], nil
+	}
+
+	if state := f.State(); state != TSOk {
+		return nil, state
+	}
+
+	if layout == LayoutState {
+		return f.Payload[1:], nil
+	}
+	return f.Payload[2:], nil
+}
+
+// Decode returns the body of the response to the given command as a value of
+// the appropriate type as decoded by the description of the command:
+//
+//	GetState                                 *State
+//	GetVersion                               *Version
+//	GetDSP                                   Measurement
+//	GetPartNumber, GetSerialNumber           string
+//	GetManufacturingDate                     string, the week and year as WWYY
+//	GetFirmwareVersion                       string, such as C.0.1.3
+//	GetFlags                                 *Flags
+//	GetTime                                  time.Time
+//	GetLast10SecEnergy                       Joules
+//	GetConfiguration                         ConfigurationState
+//	GetCumulatedEnergy                       WattHours
+//	GetCounters                              time.Duration
+//	GetLast4Alarms                           AlarmStates
+//
+// anything else is returned as the []byte from Body. The arguments of the
+// request give a DSP value the unit of its parameter, without them it has none.
+func (f *ResponseFrame) Decode(command Command, args ...Argument) (interface{}, error) {
+	body, err := f.Body(command)
+	if err != nil {
+		return nil, err
+	}
+	return decodeBody(command, args, body)
+}
+
+// decodeBody decodes the body of the response to a command
+func decodeBody(command Command, args []Argument, body []byte) (interface{}, error) {
+	if spec, ok := LookupCommand(command); ok && spec.Decode != nil {
+		return spec.Decode(body, args)
+	}
+	return body, nil
+}
+
+func (f *ResponseFrame) String() string {
+	return fmt.Sprintf("% X (%d)", f.Payload, f.CRC())
+}
+
+// decodeVar reads the big endian body into v
+func decodeVar(body []byte, v interface{}) error {
+	return binary.Read(bytes.NewReader(body), binary.BigEndian, v)
+}
+
+// decodeFirmware returns the firmware version as dot separated characters
+func decodeFirmware(body []byte) string {
+	return fmt.Sprintf("%c.%c.%c.%c",
+		rune(body[0]),
+		rune(body[1]),
+		rune(body[2]),
+		rune(body[3]),
+	)
+}
+
+// decodeTime returns the time counted in seconds from the inverter epoch
+func decodeTime(body []byte) time.Time {
+	return time.Unix(int64(InverterEpochOffset+binary.BigEndian.Uint32(body)), 0)
+}
@@ -0,0 +1,162 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package aurora_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/freman/go-aurora"
+)
+
+func TestCRC(t *testing.T) {
+	tests := []struct {
+		Data   []byte
+		Expect uint16
+	}{
+		{Data: []byte{32, 32, 32, 32, 32, 32, 32, 32}, Expect: 15784},
+		{Data: []byte{2, 56, 32, 32, 32, 32, 32, 32}, Expect: 60178},
+		{Data: []byte{2, 56, 1, 2, 3, 4, 5, 6}, Expect: 53051},
+	}
+
+	for _, test := range tests {
+		crc := aurora.CRC(test.Data)
+		if crc != test.Expect {
+			t.Errorf("CRC(%v) = %d, expected %d", test.Data, crc, test.Expect)
+		}
+	}
+}
+
+func TestRequestFrame(t *testing.T) {
+	tests := []struct {
+		Frame  *aurora.RequestFrame
+		Expect []byte
+	}{
+		{
+			Frame:  aurora.NewRequestFrame(2, aurora.GetVersion),
+			Expect: []byte{2, 58, 0, 32, 32, 32, 32, 32, 0xc9, 0x59},
+		},
+		{
+			Frame:  aurora.NewRequestFrame(2, aurora.GetDSP, aurora.DSPGridPower),
+			Expect: []byte{2, 59, 3, 0, 32, 32, 32, 32, 0xf0, 0xaa},
+		},
+		{
+			Frame:  aurora.NewRequestFrame(2, aurora.SetTime, aurora.Byte(1), aurora.Byte(2), aurora.Byte(3), aurora.Byte(4), aurora.Byte(5), aurora.Byte(6), aurora.Byte(7)),
+			Expect: []byte{2, 71, 1, 2, 3, 4, 5, 6, 0x83, 0xc7},
+		},
+	}
+
+	for _, test := range tests {
+		data, err := test.Frame.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(test.Expect, data) {
+			t.Errorf("Expected % X got % X", test.Expect, data)
+		}
+
+		var decoded aurora.RequestFrame
+		if err := decoded.UnmarshalBinary(data); err != nil {
+			t.Error(err)
+		} else if !reflect.DeepEqual(test.Frame, &decoded) {
+			t.Errorf("Expected %+v got %+v", test.Frame, decoded)
+		}
+	}
+
+	var frame aurora.RequestFrame
+	if err := frame.UnmarshalBinary([]byte{2, 58, 0, 32, 32, 32, 32, 32, 0, 0}); !errors.Is(err, aurora.ErrCRCFailure) {
+		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
+	}
+	if err := frame.UnmarshalBinary([]byte{2, 58}); !errors.Is(err, aurora.ErrShortRead) {
+		t.Errorf("Expected %v got %v", aurora.ErrShortRead, err)
+	}
+}
+
+func TestResponseFrame(t *testing.T) {
+	frame := &aurora.ResponseFrame{Payload: [6]byte{0, 6, 0x45, 0x33, 0xb8, 0}}
+	data, _ := frame.MarshalBinary()
+	if expect := []byte{0, 6, 0x45, 0x33, 0xb8, 0, 0x93, 0x29}; !bytes.Equal(expect, data) {
+		t.Errorf("Expected % X got % X", expect, data)
+	}
+
+	var decoded aurora.ResponseFrame
+	if err := decoded.UnmarshalBinary(data); err != nil || decoded != *frame {
+		t.Errorf("Expected %s got %s (%v)", frame, &decoded, err)
+	}
+	if decoded.Global() != aurora.GSRun {
+		t.Errorf("Expected %s got %s", aurora.GSRun, decoded.Global())
+	}
+
+	data[6]++
+	if err := decoded.UnmarshalBinary(data); !errors.Is(err, aurora.ErrCRCFailure) {
+		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
+	}
+}
+
+func TestResponseFrameDecode(t *testing.T) {
+	tests := []struct {
+		Command aurora.Command
+		Payload [6]byte
+		Expect  interface{}
+	}{
+		{aurora.GetState, [6]byte{0, 6, 2, 2, 2, 0}, &aurora.State{Global: aurora.GSRun, Inverter: aurora.ISRun, Channel1: aurora.DCDCMPPT, Channel2: aurora.DCDCMPPT}},
+		{aurora.GetVersion, [6]byte{0, 6, 'O', 'K', 'N', 'N'}, &aurora.Version{Model: aurora.Product3_6kWOutdoor, Regulation: aurora.ProductSpecAS4777, Transformer: aurora.InverterTransformerless, Type: aurora.InputPhotovoltaic}},
+		{aurora.GetDSP, [6]byte{0, 6, 0x45, 0x33, 0xb8, 0}, aurora.Measurement{Value: 2875.5}},
+		{aurora.GetSerialNumber, [6]byte{'1', '3', '4', '5', '1', '2'}, "134512"},
+		{aurora.GetManufacturingDate, [6]byte{0, 6, '2', '5', '1', '6'}, "2516"},
+		{aurora.GetFirmwareVersion, [6]byte{0, 6, 'C', '0', '1', '3'}, "C.0.1.3"},
+		{aurora.GetTime, [6]byte{0, 6, 0x1e, 0xe4, 0x88, 0x60}, time.Unix(1465000000, 0)},
+		{aurora.GetLast10SecEnergy, [6]byte{0, 6, 0x12, 0x34, 0, 0}, aurora.Joules(0x1234)},
+		{aurora.GetConfiguration, [6]byte{0, 6, 1, 0, 0, 0}, aurora.ConfigString1},
+		{aurora.GetCumulatedEnergy, [6]byte{0, 6, 0, 0, 0x30, 0x39}, aurora.WattHours(12345)},
+		{aurora.GetCounters, [6]byte{0, 6, 0, 0, 0x0e, 0x10}, time.Hour},
+		{aurora.GetLast4Alarms, [6]byte{0, 6, 0, 13, 34, 18}, aurora.AlarmStates{aurora.AlarmNone, aurora.AlarmGridFail, aurora.AlarmGridOF, aurora.AlarmGroundFault18}},
+		{aurora.Command(99), [6]byte{0, 6, 1, 2, 3, 4}, []byte{1, 2, 3, 4}},
+	}
+
+	for _, test := range tests {
+		frame := &aurora.ResponseFrame{Payload: test.Payload}
+		value, err := frame.Decode(test.Command)
+		if err != nil {
+			t.Errorf("%s: %v", test.Command, err)
+		} else if !reflect.DeepEqual(test.Expect, value) {
+			t.Errorf("%s: expected %v got %v", test.Command, test.Expect, value)
+		}
+	}
+
+	// Given the parameter a DSP value is in its unit
+	frame := &aurora.ResponseFrame{Payload: [6]byte{0, 6, 0x45, 0x33, 0xb8, 0}}
+	value, err := frame.Decode(aurora.GetDSP, aurora.DSPGridPower)
+	if expected := (aurora.Measurement{Value: 2875.5, Unit: aurora.UnitWatts}); err != nil || value != expected {
+		t.Errorf("Expected %v got %v (%v)", expected, value, err)
+	}
+
+	frame = &aurora.ResponseFrame{Payload: [6]byte{byte(aurora.TSVariableDoesNotExist), 6}}
+	if _, err := frame.Decode(aurora.GetDSP); !errors.Is(err, aurora.TSVariableDoesNotExist) {
+		t.Errorf("Expected %v got %v", aurora.TSVariableDoesNotExist, err)
+	}
+
+	// Text that happens to start with a transmission state is still text
+	frame = &aurora.ResponseFrame{Payload: [6]byte{'3', '4', '5', '6', '7', '8'}}
+	if value, err := frame.Decode(aurora.GetSerialNumber); err != nil || value != "345678" {
+		t.Errorf("Expected %q got %q (%v)", "345678", value, err)
+	}
+	frame = &aurora.ResponseFrame{Payload: [6]byte{byte(aurora.TSCommandNotImplemented), 6}}
+	if _, err := frame.Decode(aurora.GetPartNumber); !errors.Is(err, aurora.TSCommandNotImplemented) {
+		t.Errorf("Expected %v got %v", aurora.TSCommandNotImplemented, err)
+	}
+}
+
+func TestFrameString(t *testing.T) {
+	if str := (&aurora.RequestFrame{}).String(); str != "00 00 00 00 00 00 00 00 (33651)" {
+		t.Errorf("Unexpected string: %s", str)
+	}
+	if str := (&aurora.ResponseFrame{}).String(); str != "00 00 00 00 00 00 (63375)" {
+		t.Errorf("Unexpected string: %s", str)
+	}
+}
@@ -0,0 +1,11 @@
+module github.com/freman/go-aurora
+
+go 1.17
+
+require (
+	github.com/BurntSushi/toml v1.3.2
+	github.com/sirupsen/logrus v1.9.3
+	github.com/tarm/serial v0.0.0-20180830185346-98f6abe2eb07
+)
+
+require golang.org/x/sys v0.7.0 // indirect
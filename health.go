@@ -0,0 +1,225 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package aurora
+
+import (
+	"fmt"
+)
+
+// StateCategory is what a state means for the health of the inverter, every
+// known global, inverter and DC/DC state falls in one as given in protocol.json
+type StateCategory byte
+
+// State categories
+const (
+	CategoryUnknown   StateCategory = iota // Not a known state
+	CategoryProducing                      // Exporting power
+	CategoryWaiting                        // Waiting for sun, or not enough of it
+	CategoryStarting                       // On the way to producing
+	CategorySelfTest                       // Testing itself, usually on the way to producing
+	CategoryOff                            // Switched off or excluded on purpose
+	CategoryGrid                           // The grid is out of range, clears up by itself
+	CategoryFault                          // Something is wrong with the inverter
+	CategoryLockout                        // Something is wrong and it needs a manual reset
+)
+
+var stateCategoryStrings = map[StateCategory]string{
+	CategoryUnknown:   "unknown",
+	CategoryProducing: "producing",
+	CategoryWaiting:   "waiting",
+	CategoryStarting:  "starting",
+	CategorySelfTest:  "self_test",
+	CategoryOff:       "off",
+	CategoryGrid:      "grid",
+	CategoryFault:     "fault",
+	CategoryLockout:   "lockout",
+}
+
+// categorySeverity is how severe being in each category is
+var categorySeverity = map[StateCategory]Severity{
+	CategoryUnknown:   SeverityWarning,
+	CategoryProducing: SeverityNone,
+	CategoryWaiting:   SeverityNone,
+	CategoryStarting:  SeverityInfo,
+	CategorySelfTest:  SeverityInfo,
+	CategoryOff:       SeverityInfo,
+	CategoryGrid:      SeverityWarning,
+	CategoryFault:     SeverityError,
+	CategoryLockout:   SeverityCritical,
+}
+
+func (c StateCategory) String() string {
+	if str, ok := stateCategoryStrings[c]; ok {
+		return str
+	}
+	return fmt.Sprintf("Unknown StateCategory(%d)", c)
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (c StateCategory) MarshalText() ([]byte, error) {
+	if _, ok := stateCategoryStrings[c]; !ok {
+		return nil, fmt.Errorf("Unknown StateCategory(%d)", c)
+	}
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (c *StateCategory) UnmarshalText(text []byte) error {
+	for category, str := range stateCategoryStrings {
+		if str == string(text) {
+			*c = category
+			return nil
+		}
+	}
+	return fmt.Errorf("Unknown state category %q", text)
+}
+
+// Severity returns how severe being in the category is
+func (c StateCategory) Severity() Severity {
+	if severity, ok := categorySeverity[c]; ok {
+		return severity
+	}
+	return SeverityWarning
+}
+
+// IsFault returns true if the category is the grid, a fault or a lockout
+func (c StateCategory) IsFault() bool {
+	return c == CategoryGrid || c == CategoryFault || c == CategoryLockout
+}
+
+// Severity is how much attention a state or alarm needs, higher is worse
+type Severity byte
+
+// Severities
+const (
+	SeverityNone     Severity = iota // All is well
+	SeverityInfo                     // Worth knowing about
+	SeverityWarning                  // Not producing, likely to clear up by itself
+	SeverityError                    // Not producing, needs looking at
+	SeverityCritical                 // Not producing until someone resets it
+)
+
+var severityStrings = map[Severity]string{
+	SeverityNone:     "none",
+	SeverityInfo:     "info",
+	SeverityWarning:  "warning",
+	SeverityError:    "error",
+	SeverityCritical: "critical",
+}
+
+func (s Severity) String() string {
+	if str, ok := severityStrings[s]; ok {
+		return str
+	}
+	return fmt.Sprintf("Unknown Severity(%d)", s)
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (s Severity) MarshalText() ([]byte, error) {
+	if _, ok := severityStrings[s]; !ok {
+		return nil, fmt.Errorf("Unknown Severity(%d)", s)
+	}
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (s *Severity) UnmarshalText(text []byte) error {
+	for severity, str := range severityStrings {
+		if str == string(text) {
+			*s = severity
+			return nil
+		}
+	}
+	return fmt.Errorf("Unknown severity %q", text)
+}
+
+// Category returns what the state means for the health of the inverter
+func (s GlobalState) Category() StateCategory { return globalStateCategories[s] }
+
+// Severity returns how severe being in the state is
+func (s GlobalState) Severity() Severity { return s.Category().Severity() }
+
+// IsProducing returns true if the inverter is exporting power
+func (s GlobalState) IsProducing() bool { return s.Category() == CategoryProducing }
+
+// IsWaiting returns true if the inverter is waiting for sun
+func (s GlobalState) IsWaiting() bool { return s.Category() == CategoryWaiting }
+
+// IsFault returns true if the state is a fault, see StateCategory.IsFault
+func (s GlobalState) IsFault() bool { return s.Category().IsFault() }
+
+// Category returns what the state means for the health of the inverter
+func (s InverterState) Category() StateCategory { return inverterStateCategories[s] }
+
+// Severity returns how severe being in the state is
+func (s InverterState) Severity() Severity { return s.Category().Severity() }
+
+// IsProducing returns true if the inverter is exporting power
+func (s InverterState) IsProducing() bool { return s.Category() == CategoryProducing }
+
+// IsWaiting returns true if the inverter is waiting for sun
+func (s InverterState) IsWaiting() bool { return s.Category() == CategoryWaiting }
+
+// IsFault returns true if the state is a fault, see StateCategory.IsFault
+func (s InverterState) IsFault() bool { return s.Category().IsFault() }
+
+// Category returns what the state means for the health of the inverter
+func (s DCDCState) Category() StateCategory { return dcdcStateCategories[s] }
+
+// Severity returns how severe being in the state is
+func (s DCDCState) Severity() Severity { return s.Category().Severity() }
+
+// IsProducing returns true if the channel is tracking its input
+func (s DCDCState) IsProducing() bool { return s.Category() == CategoryProducing }
+
+// IsWaiting returns true if the channel is waiting for sun
+func (s DCDCState) IsWaiting() bool { return s.Category() == CategoryWaiting }
+
+// IsFault returns true if the state is a fault, see StateCategory.IsFault
+func (s DCDCState) IsFault() bool { return s.Category().IsFault() }
+
+// Category returns the category of the most severe of the global, inverter and
+// channel states, the global state's should they be as severe as each other
+func (s *State) Category() StateCategory {
+	category := s.Global.Category()
+	for _, c := range []StateCategory{s.Inverter.Category(), s.Channel1.Category(), s.Channel2.Category()} {
+		if c.Severity() > category.Severity() {
+			category = c
+		}
+	}
+	return category
+}
+
+// Severity returns the severity of the most severe of the global, inverter and
+// channel states and the alarm
+func (s *State) Severity() Severity {
+	severity := s.Category().Severity()
+	if alarm := s.Alarm.Info().Severity; alarm > severity {
+		return alarm
+	}
+	return severity
+}
+
+// IsProducing returns true if the inverter is exporting power
+func (s *State) IsProducing() bool { return s.Global.IsProducing() }
+
+// IsWaiting returns true if the inverter is waiting for sun
+func (s *State) IsWaiting() bool { return s.Global.IsWaiting() }
+
+// IsFault returns true if any of the global, inverter or channel states is a fault
+func (s *State) IsFault() bool {
+	return s.Global.IsFault() || s.Inverter.IsFault() || s.Channel1.IsFault() || s.Channel2.IsFault()
+}
+
+// NeedsReset returns true if any of the global, inverter or channel states
+// needs a manual reset
+func (s *State) NeedsReset() bool {
+	for _, c := range []StateCategory{s.Global.Category(), s.Inverter.Category(), s.Channel1.Category(), s.Channel2.Category()} {
+		if c == CategoryLockout {
+			return true
+		}
+	}
+	return false
+}
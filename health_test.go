@@ -0,0 +1,87 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package aurora_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/freman/go-aurora"
+)
+
+func TestStateCategories(t *testing.T) {
+	tests := []struct {
+		state    interface{ Category() aurora.StateCategory }
+		expected aurora.StateCategory
+	}{
+		{aurora.GSRun, aurora.CategoryProducing},
+		{aurora.GSWaitingSun, aurora.CategoryWaiting},
+		{aurora.GSSelfTest, aurora.CategorySelfTest},
+		{aurora.GSRemoteOff, aurora.CategoryOff},
+		{aurora.GSWaitingManualReset, aurora.CategoryLockout},
+		{aurora.GlobalState(250), aurora.CategoryUnknown},
+		{aurora.ISMPPT, aurora.CategoryProducing},
+		{aurora.ISGridOverVoltage, aurora.CategoryGrid},
+		{aurora.ISBulkCapFail, aurora.CategoryFault},
+		{aurora.DCDCInputLow, aurora.CategoryWaiting},
+		{aurora.DCDCGroundFault, aurora.CategoryLockout},
+	}
+	for _, test := range tests {
+		if got := test.state.Category(); got != test.expected {
+			t.Errorf("Expected %v for %v got %v", test.expected, test.state, got)
+		}
+	}
+
+	if !aurora.GSRun.IsProducing() || aurora.GSRun.IsFault() || aurora.GSRun.Severity() != aurora.SeverityNone {
+		t.Errorf("Unexpected classification of %v", aurora.GSRun)
+	}
+	if !aurora.ISGRIDFAIL.IsFault() || aurora.ISGRIDFAIL.Severity() != aurora.SeverityWarning {
+		t.Errorf("Unexpected classification of %v", aurora.ISGRIDFAIL)
+	}
+	if !aurora.DCDCOff.IsWaiting() || aurora.DCDCOff.IsFault() {
+		t.Errorf("Unexpected classification of %v", aurora.DCDCOff)
+	}
+}
+
+func TestStateHealth(t *testing.T) {
+	state := &aurora.State{
+		Global:   aurora.GSRun,
+		Inverter: aurora.ISRun,
+		Channel1: aurora.DCDCMPPT,
+		Channel2: aurora.DCDCInputLow,
+	}
+	if !state.IsProducing() || state.IsFault() || state.NeedsReset() || state.Category() != aurora.CategoryProducing || state.Severity() != aurora.SeverityNone {
+		t.Errorf("Expected %v to be healthy, got %v (%v)", state, state.Category(), state.Severity())
+	}
+
+	// The worst of the parts wins
+	state.Global = aurora.GSWaitingSunGrid
+	state.Inverter = aurora.ISGridNotPresent
+	state.Channel1 = aurora.DCDCILEAKFail
+	if state.IsProducing() || !state.IsFault() || !state.NeedsReset() || state.Category() != aurora.CategoryLockout || state.Severity() != aurora.SeverityCritical {
+		t.Errorf("Expected %v to need a reset, got %v (%v)", state, state.Category(), state.Severity())
+	}
+}
+
+func TestSeverityText(t *testing.T) {
+	data, err := json.Marshal(map[string]interface{}{"category": aurora.CategoryGrid, "severity": aurora.SeverityWarning})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"category":"grid","severity":"warning"}` {
+		t.Errorf("Unexpected %s", data)
+	}
+
+	var s aurora.Severity
+	if err := s.UnmarshalText([]byte("critical")); err != nil || s != aurora.SeverityCritical {
+		t.Errorf("Expected %v got %v (%v)", aurora.SeverityCritical, s, err)
+	}
+	if err := s.UnmarshalText([]byte("dire")); err == nil {
+		t.Error("Expected error for an unknown severity")
+	}
+	if aurora.SeverityError <= aurora.SeverityWarning {
+		t.Error("Expected errors to be more severe than warnings")
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package aurora
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Firmware is a firmware release, 4 characters usually written separated by
+// dots as in C.0.1.3. Releases compare character by character.
+type Firmware [4]byte
+
+// ParseFirmware parses a firmware release written with or without dots
+func ParseFirmware(s string) (Firmware, error) {
+	var f Firmware
+	chars := strings.Replace(s, ".", "", -1)
+	if len(chars) != len(f) {
+		return f, fmt.Errorf("Invalid firmware release %q", s)
+	}
+	copy(f[:], chars)
+	return f, nil
+}
+
+func (f Firmware) String() string {
+	return decodeFirmware(f[:])
+}
+
+// Compare returns -1 if f is older than o, 1 if it is newer and 0 if they are
+// the same release
+func (f Firmware) Compare(o Firmware) int {
+	for n := range f {
+		if f[n] < o[n] {
+			return -1
+		} else if f[n] > o[n] {
+			return 1
+		}
+	}
+	return 0
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (f Firmware) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (f *Firmware) UnmarshalText(text []byte) (err error) {
+	*f, err = ParseFirmware(string(text))
+	return
+}
+
+// DeviceInfo holds everything about an inverter that doesn't change, as
+// returned by Identify
+type DeviceInfo struct {
+	Address       byte               `json:"address"`
+	Model         Product            `json:"model"`
+	Regulation    ProductSpec        `json:"regulation"`
+	Transformer   InverterType       `json:"transformer"`
+	Type          InputType          `json:"type"`
+	PartNumber    string             `json:"part_number"`
+	SerialNumber  string             `json:"serial_number"`
+	Firmware      Firmware           `json:"firmware"`
+	Manufactured  time.Time          `json:"manufactured"` // Monday of the ISO week of manufacture
+	Configuration ConfigurationState `json:"configuration"`
+}
+
+// Version returns the version the model, regulation and types came from
+func (d *DeviceInfo) Version() *Version {
+	return &Version{
+		Model:       d.Model,
+		Regulation:  d.Regulation,
+		Transformer: d.Transformer,
+		Type:        d.Type,
+	}
+}
+
+// String returns the device info as easy to read lines
+func (d *DeviceInfo) String() string {
+	year, week := d.Manufactured.ISOWeek()
+	return fmt.Sprintf(`Address: %d
+Model: %s
+Regulation: %s
+Transformer: %s
+Type: %s
+Part #: %s
+Serial #: %s
+Firmware: %s
+Manufactured: week %d of %d
+String Configuration: %s
+`,
+		d.Address,
+		d.Model,
+		d.Regulation,
+		d.Transformer,
+		d.Type,
+		d.PartNumber,
+		d.SerialNumber,
+		d.Firmware,
+		week, year,
+		d.Configuration,
+	)
+}
+
+// MarshalText implements encoding.TextMarshaler, see String
+func (d *DeviceInfo) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// deviceInfo has the fields of DeviceInfo without its methods
+type deviceInfo DeviceInfo
+
+// MarshalJSON implements json.Marshaler, encoding the fields of the device info
+// rather than the text
+func (d *DeviceInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal((*deviceInfo)(d))
+}
+
+// Identify gathers the version, part and serial numbers, firmware release, date
+// of manufacture and string configuration of the inverter. Only the version is
+// a must, anything else the inverter doesn't support is left at its zero value
+// bar the configuration, as zero is ConfigBoth, which is left unknown (255).
+func (i *Inverter) Identify() (*DeviceInfo, error) {
+	return i.IdentifyContext(context.Background())
+}
+
+// IdentifyContext works much like Identify but gives up once the context is done
+func (i *Inverter) IdentifyContext(ctx context.Context) (*DeviceInfo, error) {
+	version, err := i.VersionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &DeviceInfo{
+		Address:     i.Address,
+		Model:       version.Model,
+		Regulation:  version.Regulation,
+		Transformer: version.Transformer,
+		Type:        version.Type,
+	}
+
+	partNumber, err := i.PartNumberContext(ctx)
+	if err = optional(err); err != nil {
+		return nil, err
+	}
+	d.PartNumber = strings.TrimSpace(partNumber)
+
+	serialNumber, err := i.SerialNumberContext(ctx)
+	if err = optional(err); err != nil {
+		return nil, err
+	}
+	d.SerialNumber = strings.TrimSpace(serialNumber)
+
+	firmware, err := i.CommunicateContext(ctx, GetFirmwareVersion)
+	if err = optional(err); err != nil {
+		return nil, err
+	}
+	copy(d.Firmware[:], firmware)
+
+	year, week, err := i.ManufactureDateContext(ctx)
+	if err == nil {
+		if d.Manufactured, err = isoWeek(year, week); err != nil {
+			return nil, i.newError(ctx, GetManufacturingDate, err)
+		}
+	} else if err = optional(err); err != nil {
+		return nil, err
+	}
+
+	configuration, err := i.ConfigurationContext(ctx)
+	if err = optional(err); err != nil {
+		return nil, err
+	}
+	d.Configuration = configuration
+
+	return d, nil
+}
+
+// optional returns nil should err say the inverter doesn't support what was
+// asked for, otherwise err
+func optional(err error) error {
+	if errors.Is(err, TSCommandNotImplemented) || errors.Is(err, TSVariableDoesNotExist) {
+		return nil
+	}
+	return err
+}
+
+// isoWeek returns midnight UTC on the Monday of the ISO week of the year given
+// as two digits each, as reported by the inverter
+func isoWeek(year, week string) (time.Time, error) {
+	y, err := strconv.Atoi(strings.TrimSpace(year))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Invalid year of manufacture %q", year)
+	}
+	w, err := strconv.Atoi(strings.TrimSpace(week))
+	if err != nil || w < 1 || w > 53 {
+		return time.Time{}, fmt.Errorf("Invalid week of manufacture %q", week)
+	}
+
+	// The 4th of January is always in the first week
+	jan4 := time.Date(2000+y, time.January, 4, 0, 0, 0, 0, time.UTC)
+	monday := jan4.AddDate(0, 0, -(int(jan4.Weekday())+6)%7)
+	return monday.AddDate(0, 0, (w-1)*7), nil
+}
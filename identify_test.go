@@ -0,0 +1,163 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package aurora_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/freman/go-aurora"
+	"github.com/freman/go-aurora/aurorasim"
+)
+
+func TestIdentify(t *testing.T) {
+	sim := aurorasim.NewInverter(2)
+	sim.SerialNumber = "1234"
+
+	info, err := simulatedInverter(t, sim).IdentifyContext(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &aurora.DeviceInfo{
+		Address:       2,
+		Model:         sim.Version.Model,
+		Regulation:    sim.Version.Regulation,
+		Transformer:   sim.Version.Transformer,
+		Type:          sim.Version.Type,
+		PartNumber:    "-3G79-",
+		SerialNumber:  "1234",
+		Firmware:      aurora.Firmware{'C', '0', '1', '3'},
+		Manufactured:  time.Date(2016, time.June, 20, 0, 0, 0, 0, time.UTC),
+		Configuration: aurora.ConfigBoth,
+	}
+	if !reflect.DeepEqual(expected, info) {
+		t.Errorf("Expected %+v got %+v", expected, info)
+	}
+	if version := info.Version(); *version != sim.Version {
+		t.Errorf("Expected %v got %v", sim.Version, *version)
+	}
+
+	text, err := info.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"Serial #: 1234\n", "Firmware: C.0.1.3\n", "Manufactured: week 25 of 2016\n"} {
+		if !strings.Contains(string(text), line) {
+			t.Errorf("Expected %q in %q", line, text)
+		}
+	}
+
+	data, err := json.Marshal(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"firmware":"C.0.1.3"`) {
+		t.Errorf("Expected firmware as text in %s", data)
+	}
+	var decoded aurora.DeviceInfo
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*expected, decoded) {
+		t.Errorf("Expected %+v got %+v", *expected, decoded)
+	}
+}
+
+func TestIdentifyUnsupported(t *testing.T) {
+	sim := aurorasim.NewInverter(2)
+	sim.Fail(aurora.GetPartNumber, aurora.TSCommandNotImplemented, 0)
+	sim.Fail(aurora.GetManufacturingDate, aurora.TSCommandNotImplemented, 0)
+	sim.Fail(aurora.GetConfiguration, aurora.TSVariableDoesNotExist, 0)
+
+	info, err := simulatedInverter(t, sim).Identify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.PartNumber != "" || !info.Manufactured.IsZero() || info.Configuration.Known() {
+		t.Errorf("Expected no part number, date of manufacture or configuration got %+v", info)
+	}
+	if info.SerialNumber != "123456" || info.Firmware != (aurora.Firmware{'C', '0', '1', '3'}) {
+		t.Errorf("Expected the rest regardless got %+v", info)
+	}
+
+	sim.Fail(aurora.GetVersion, aurora.TSCommandNotImplemented, 0)
+	if _, err := simulatedInverter(t, sim).Identify(); !errors.Is(err, aurora.TSCommandNotImplemented) {
+		t.Errorf("Expected %v got %v", aurora.TSCommandNotImplemented, err)
+	}
+}
+
+func TestIdentifyManufactureDate(t *testing.T) {
+	tests := []struct {
+		year, week string
+		expected   time.Time
+	}{
+		{"09", "01", time.Date(2008, time.December, 29, 0, 0, 0, 0, time.UTC)},
+		{"15", "53", time.Date(2015, time.December, 28, 0, 0, 0, 0, time.UTC)},
+		{"21", "01", time.Date(2021, time.January, 4, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		sim := aurorasim.NewInverter(2)
+		sim.ManufactureYear, sim.ManufactureWeek = test.year, test.week
+
+		info, err := simulatedInverter(t, sim).Identify()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !info.Manufactured.Equal(test.expected) {
+			t.Errorf("Expected %v for week %s of %s got %v", test.expected, test.week, test.year, info.Manufactured)
+		}
+	}
+
+	sim := aurorasim.NewInverter(2)
+	sim.ManufactureWeek = "??"
+	if _, err := simulatedInverter(t, sim).Identify(); err == nil {
+		t.Error("Expected error for an invalid week of manufacture")
+	}
+}
+
+func TestFirmware(t *testing.T) {
+	for _, s := range []string{"C.0.1.3", "C013"} {
+		f, err := aurora.ParseFirmware(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.String() != "C.0.1.3" {
+			t.Errorf("Expected C.0.1.3 got %s", f)
+		}
+	}
+
+	if _, err := aurora.ParseFirmware("C.0.1"); err == nil {
+		t.Error("Expected error for a short release")
+	}
+
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"C.0.1.3", "C.0.1.3", 0},
+		{"C.0.1.3", "C.0.1.4", -1},
+		{"C.0.2.0", "C.0.1.9", 1},
+		{"B.9.9.9", "C.0.0.0", -1},
+	}
+	for _, test := range tests {
+		a, _ := aurora.ParseFirmware(test.a)
+		b, _ := aurora.ParseFirmware(test.b)
+		if got := a.Compare(b); got != test.expected {
+			t.Errorf("Expected %s compared to %s to be %d got %d", test.a, test.b, test.expected, got)
+		}
+	}
+
+	var f aurora.Firmware
+	if err := f.UnmarshalText([]byte("A.1.2.3")); err != nil || f != (aurora.Firmware{'A', '1', '2', '3'}) {
+		t.Errorf("Unexpected %v, %v", f, err)
+	}
+}
@@ -0,0 +1,447 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+/*
+Protogen generates the enum constants, their string and name tables and their
+text and JSON encodings of the aurora package from the protocol spec,
+protocol.json.
+
+The spec lists each enumerated type of the protocol along with its values:
+
+	{"const": "GetState", "value": 50, "name": "get_state", "text": "State Request", "comment": "Get the inverter state"}
+
+const is the name of the Go constant, value is the byte on the wire, either a
+number or a single character, name is the snake_case name the value is looked
+up by and marshalled as, text is what String() returns and comment is the
+optional comment on the constant.
+
+Values that are measurements may also give their unit, the name of one of the
+UnitOfMeasure constants without its Unit prefix:
+
+	{"const": "DSPGridPower", "value": 3, "name": "grid_power", "text": "Grid Power (Global)", "unit": "Watts"}
+
+States give the category they fall in, the name of one of the StateCategory
+constants without its Category prefix:
+
+	{"const": "GSRun", "value": 6, "name": "run", "text": "Run", "category": "Producing"}
+
+Alarms give the code shown on the display of the inverter if they have one, a
+severity, the name of one of the Severity constants without its prefix, and
+the likely cause and what to do about it:
+
+	{"const": "AlarmGridFail", "value": 13, "name": "grid_fail", "text": "Grid Fail W003", "code": "W003", "severity": "Warning", "cause": "...", "action": "..."}
+
+It is run by go generate in the root of the repository:
+
+	go generate github.com/freman/go-aurora
+*/
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Spec is the protocol spec
+type Spec struct {
+	Enums []*Enum `json:"enums"`
+}
+
+// Enum is an enumerated type of the protocol
+type Enum struct {
+	Type   string   `json:"type"`
+	Doc    string   `json:"doc"`
+	Values []*Value `json:"values"`
+}
+
+// Value is a single value of an enumerated type
+type Value struct {
+	Const    string          `json:"const"`
+	Value    json.RawMessage `json:"value"`
+	Name     string          `json:"name"`
+	Text     string          `json:"text"`
+	Comment  string          `json:"comment,omitempty"`
+	Unit     string          `json:"unit,omitempty"`
+	Category string          `json:"category,omitempty"`
+	Code     string          `json:"code,omitempty"`
+	Severity string          `json:"severity,omitempty"`
+	Cause    string          `json:"cause,omitempty"`
+	Action   string          `json:"action,omitempty"`
+
+	// Literal is the value as a Go literal
+	Literal string `json:"-"`
+}
+
+var (
+	identRe = regexp.MustCompile(`^[A-Z][A-Za-z0-9_]*$`)
+	nameRe  = regexp.MustCompile(`^[a-z0-9]+(_[a-z0-9]+)*$`)
+	codeRe  = regexp.MustCompile(`^[EW][0-9]{3}$`)
+)
+
+func main() {
+	fSpec := flag.String("spec", "protocol.json", "Protocol spec to generate from")
+	fOut := flag.String("o", ".", "Directory to write the generated files to")
+	flag.Parse()
+
+	f, err := os.Open(*fSpec)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	spec, err := Load(f)
+	if err != nil {
+		log.Fatalf("%s: %v", *fSpec, err)
+	}
+
+	files, err := Generate(spec)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(*fOut, name), src, 0644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// Load reads and checks the spec
+func Load(r io.Reader) (*Spec, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+
+	var spec Spec
+	if err := dec.Decode(&spec); err != nil {
+		return nil, err
+	}
+
+	types := map[string]bool{}
+	for _, enum := range spec.Enums {
+		if !identRe.MatchString(enum.Type) {
+			return nil, fmt.Errorf("invalid type %q", enum.Type)
+		}
+		if types[enum.Type] {
+			return nil, fmt.Errorf("duplicate type %s", enum.Type)
+		}
+		types[enum.Type] = true
+
+		if err := enum.check(); err != nil {
+			return nil, fmt.Errorf("%s: %v", enum.Type, err)
+		}
+	}
+
+	return &spec, nil
+}
+
+// check validates the values of the enum and works out their literals
+func (e *Enum) check() error {
+	consts := map[string]bool{}
+	names := map[string]bool{}
+	values := map[string]string{}
+
+	for _, v := range e.Values {
+		if !identRe.MatchString(v.Const) {
+			return fmt.Errorf("invalid const %q", v.Const)
+		}
+		if consts[v.Const] {
+			return fmt.Errorf("duplicate const %s", v.Const)
+		}
+		consts[v.Const] = true
+
+		if !nameRe.MatchString(v.Name) {
+			return fmt.Errorf("%s: name %q is not snake_case", v.Const, v.Name)
+		}
+		if _, err := strconv.Atoi(v.Name); err == nil {
+			// It would be mistaken for a number when unmarshalled
+			return fmt.Errorf("%s: name %q is a number", v.Const, v.Name)
+		}
+		if names[v.Name] {
+			return fmt.Errorf("%s: duplicate name %s", v.Const, v.Name)
+		}
+		names[v.Name] = true
+
+		if v.Text == "" {
+			return fmt.Errorf("%s: missing text", v.Const)
+		}
+
+		if v.Unit != "" && !identRe.MatchString(v.Unit) {
+			return fmt.Errorf("%s: invalid unit %q", v.Const, v.Unit)
+		}
+		if v.Category != "" && !identRe.MatchString(v.Category) {
+			return fmt.Errorf("%s: invalid category %q", v.Const, v.Category)
+		}
+
+		if v.Code != "" && !codeRe.MatchString(v.Code) {
+			return fmt.Errorf("%s: invalid code %q", v.Const, v.Code)
+		}
+		if v.Severity != "" && !identRe.MatchString(v.Severity) {
+			return fmt.Errorf("%s: invalid severity %q", v.Const, v.Severity)
+		}
+		if (v.Code != "" || v.Cause != "" || v.Action != "") && v.Severity == "" {
+			return fmt.Errorf("%s: missing severity", v.Const)
+		}
+
+		literal, b, err := parseValue(v.Value)
+		if err != nil {
+			return fmt.Errorf("%s: %v", v.Const, err)
+		}
+		if other, ok := values[b]; ok {
+			return fmt.Errorf("%s: value %s already used by %s", v.Const, literal, other)
+		}
+		values[b] = v.Const
+		v.Literal = literal
+	}
+
+	return nil
+}
+
+// HasUnits returns true if any of the values of the enum have a unit
+func (e *Enum) HasUnits() bool {
+	for _, v := range e.Values {
+		if v.Unit != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// HasCategories returns true if any of the values of the enum have a category
+func (e *Enum) HasCategories() bool {
+	for _, v := range e.Values {
+		if v.Category != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// HasSeverities returns true if any of the values of the enum have a severity
+func (e *Enum) HasSeverities() bool {
+	for _, v := range e.Values {
+		if v.Severity != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// parseValue returns the Go literal of a value and the byte it represents
+func parseValue(raw json.RawMessage) (string, string, error) {
+	if len(raw) == 0 {
+		return "", "", errors.New("missing value")
+	}
+
+	if raw[0] == '"' {
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return "", "", err
+		}
+		r, size := utf8.DecodeRuneInString(s)
+		if size != len(s) || r > unicode.MaxASCII {
+			return "", "", fmt.Errorf("value %s is not a single character", raw)
+		}
+		return strconv.QuoteRune(r), strconv.Itoa(int(r)), nil
+	}
+
+	var b uint8
+	if err := json.Unmarshal(raw, &b); err != nil {
+		return "", "", fmt.Errorf("value %s is not a byte", raw)
+	}
+	return strconv.Itoa(int(b)), strconv.Itoa(int(b)), nil
+}
+
+// Generate returns the source of the generated files keyed by file name
+func Generate(spec *Spec) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	for name, tmpl := range map[string]*template.Template{
+		"constants.go": constantsTemplate,
+		"strings.go":   stringsTemplate,
+		"text.go":      textTemplate,
+	} {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, spec); err != nil {
+			return nil, err
+		}
+
+		src, err := format.Source(buf.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+		files[name] = src
+	}
+	return files, nil
+}
+
+// unexported returns the type name as an unexported identifier, DSParameter
+// becomes dsParameter and DCDCState dcdcState
+func unexported(name string) string {
+	runes := []rune(name)
+	upper := 0
+	for upper < len(runes) && unicode.IsUpper(runes[upper]) {
+		upper++
+	}
+	if upper > 1 && upper < len(runes) {
+		// Leave the start of the next word alone
+		upper--
+	}
+	return strings.ToLower(string(runes[:upper])) + string(runes[upper:])
+}
+
+var funcs = template.FuncMap{
+	"unexported": unexported,
+	"quote":      strconv.Quote,
+}
+
+const header = `// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// Code generated by protogen from protocol.json. DO NOT EDIT.
+
+package aurora
+`
+
+var constantsTemplate = template.Must(template.New("constants").Funcs(funcs).Parse(header + `
+{{range .Enums}}
+// {{.Doc}}
+const (
+{{- $type := .Type}}
+{{- range .Values}}
+	{{.Const}} {{$type}} = {{.Literal}}{{if .Comment}} // {{.Comment}}{{end}}
+{{- end}}
+)
+{{end}}`))
+
+var stringsTemplate = template.Must(template.New("strings").Funcs(funcs).Parse(header + `
+{{range .Enums}}
+{{- $type := .Type}}{{$var := unexported .Type}}
+var {{$var}}Strings = map[{{$type}}]string{
+{{- range .Values}}
+	{{.Const}}: {{quote .Text}},
+{{- end}}
+}
+
+var {{$var}}Values = []{{$type}}{
+{{- range .Values}}
+	{{.Const}},
+{{- end}}
+}
+
+var {{$var}}Names = map[string]{{$type}}{
+{{- range .Values}}
+	{{quote .Name}}: {{.Const}},
+{{- end}}
+}
+
+// {{$type}}ByName returns the {{$type}} with the given snake_case name
+func {{$type}}ByName(name string) ({{$type}}, bool) {
+	v, ok := {{$var}}Names[name]
+	return v, ok
+}
+
+// Known returns true if the {{$type}} is described by the protocol spec
+func (v {{$type}}) Known() bool {
+	_, ok := {{$var}}Strings[v]
+	return ok
+}
+{{- if .HasUnits}}
+
+var {{$var}}Units = map[{{$type}}]UnitOfMeasure{
+{{- range .Values}}{{if .Unit}}
+	{{.Const}}: Unit{{.Unit}},
+{{- end}}{{end}}
+}
+{{- end}}
+{{- if .HasCategories}}
+
+var {{$var}}Categories = map[{{$type}}]StateCategory{
+{{- range .Values}}{{if .Category}}
+	{{.Const}}: Category{{.Category}},
+{{- end}}{{end}}
+}
+{{- end}}
+{{- if .HasSeverities}}
+
+var {{$var}}Info = map[{{$type}}]AlarmInfo{
+{{- range .Values}}{{if .Severity}}
+	{{.Const}}: {Code: {{quote .Code}}, Severity: Severity{{.Severity}}, Cause: {{quote .Cause}}, Action: {{quote .Action}}},
+{{- end}}{{end}}
+}
+{{- end}}
+{{end}}`))
+
+var textTemplate = template.Must(template.New("text").Funcs(funcs).Parse(header + `
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+{{range .Enums}}
+{{- $type := .Type}}{{$var := unexported .Type}}
+// Name returns the snake_case name of the {{$type}}, its number if unknown
+func (v {{$type}}) Name() string {
+	switch v {
+{{- range .Values}}
+	case {{.Const}}:
+		return {{quote .Name}}
+{{- end}}
+	}
+	return strconv.Itoa(int(v))
+}
+
+// MarshalText implements encoding.TextMarshaler, see Name
+func (v {{$type}}) MarshalText() ([]byte, error) {
+	return []byte(v.Name()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting a name or a number
+func (v *{{$type}}) UnmarshalText(text []byte) error {
+	if value, ok := {{$var}}Names[string(text)]; ok {
+		*v = value
+		return nil
+	}
+	n, err := strconv.ParseUint(string(text), 10, 8)
+	if err != nil {
+		return fmt.Errorf("Unknown {{$type}} %q", text)
+	}
+	*v = {{$type}}(n)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting a name or a number as
+// either a string or a number
+func (v *{{$type}}) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		return v.UnmarshalText([]byte(text))
+	}
+	if string(data) == "null" {
+		return nil
+	}
+	var n uint8
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("Unknown {{$type}} %s", data)
+	}
+	*v = {{$type}}(n)
+	return nil
+}
+{{end}}`))
@@ -0,0 +1,74 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGeneratedUpToDate(t *testing.T) {
+	f, err := os.Open(filepath.Join("..", "..", "protocol.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	spec, err := Load(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := Generate(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, src := range files {
+		existing, err := os.ReadFile(filepath.Join("..", "..", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(existing, src) {
+			t.Errorf("%s is out of date, run go generate", name)
+		}
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	for _, spec := range []string{
+		`{"enums": [{"type": "Command", "values": [{"const": "GetState", "value": 256, "name": "get_state", "text": "State"}]}]}`,
+		`{"enums": [{"type": "Command", "values": [{"const": "GetState", "value": "ab", "name": "get_state", "text": "State"}]}]}`,
+		`{"enums": [{"type": "Command", "values": [{"const": "GetState", "value": 50, "name": "GetState", "text": "State"}]}]}`,
+		`{"enums": [{"type": "Command", "values": [{"const": "GetState", "value": 50, "name": "get_state"}]}]}`,
+		`{"enums": [{"type": "Command", "values": [{"const": "GetState", "value": 50, "name": "get_state", "text": "State"}, {"const": "GetOther", "value": 50, "name": "get_other", "text": "Other"}]}]}`,
+		`{"enums": [{"type": "Command", "values": [{"const": "GetState", "value": 50, "name": "get_state", "text": "State", "typo": true}]}]}`,
+		`{"enums": [{"type": "Command", "values": [{"const": "GetState", "value": 50, "name": "get_state", "text": "State", "unit": "watts"}]}]}`,
+		`{"enums": [{"type": "Command", "values": [{"const": "GetState", "value": 50, "name": "50", "text": "State"}]}]}`,
+		`{"enums": [{"type": "Command", "values": [{"const": "GetState", "value": 50, "name": "get_state", "text": "State", "category": "run time"}]}]}`,
+		`{"enums": [{"type": "AlarmState", "values": [{"const": "AlarmNone", "value": 0, "name": "none", "text": "No Alarm", "code": "X001", "severity": "None"}]}]}`,
+		`{"enums": [{"type": "AlarmState", "values": [{"const": "AlarmNone", "value": 0, "name": "none", "text": "No Alarm", "cause": "Nothing"}]}]}`,
+	} {
+		if _, err := Load(strings.NewReader(spec)); err == nil {
+			t.Errorf("Expected error loading %s", spec)
+		}
+	}
+}
+
+func TestUnexported(t *testing.T) {
+	for name, expect := range map[string]string{
+		"Command":           "command",
+		"DSParameter":       "dsParameter",
+		"DCDCState":         "dcdcState",
+		"TransmissionState": "transmissionState",
+	} {
+		if got := unexported(name); got != expect {
+			t.Errorf("Expected %s got %s", expect, got)
+		}
+	}
+}
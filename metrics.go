@@ -0,0 +1,212 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package aurora
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// MetricKind is how a metric changes over time
+type MetricKind byte
+
+// Kinds of metric
+const (
+	MetricGauge   MetricKind = iota // Goes up and down
+	MetricCounter                   // Only goes up, other than when it is reset
+)
+
+var metricKindStrings = map[MetricKind]string{
+	MetricGauge:   "gauge",
+	MetricCounter: "counter",
+}
+
+func (k MetricKind) String() string {
+	if str, ok := metricKindStrings[k]; ok {
+		return str
+	}
+	return fmt.Sprintf("Unknown MetricKind(%d)", k)
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (k MetricKind) MarshalText() ([]byte, error) {
+	if _, ok := metricKindStrings[k]; !ok {
+		return nil, fmt.Errorf("Unknown MetricKind(%d)", k)
+	}
+	return []byte(k.String()), nil
+}
+
+// Metric describes a single measurement and how to read it, see Metrics
+type Metric struct {
+	// Name is the stable snake_case name of the metric, such as grid_power or
+	// energy_daily
+	Name string
+	Text string
+
+	// Command and Argument read the metric, Argument is nil for commands that
+	// take none
+	Command  Command
+	Argument Argument
+
+	Unit UnitOfMeasure
+	Kind MetricKind
+
+	// Models the metric applies to, empty if it applies to all of them
+	Models []Product
+}
+
+func (m *Metric) String() string {
+	return m.Name
+}
+
+// AppliesTo returns true if inverters of the model have the metric
+func (m *Metric) AppliesTo(model Product) bool {
+	if len(m.Models) == 0 {
+		return true
+	}
+	for _, p := range m.Models {
+		if p == model {
+			return true
+		}
+	}
+	return false
+}
+
+// SupportedBy returns true if an inverter with the capabilities can be asked
+// for the metric, nil capabilities support everything
+func (m *Metric) SupportedBy(c *Capabilities) bool {
+	if c == nil {
+		return true
+	}
+	if !m.AppliesTo(c.Version.Model) {
+		return false
+	}
+	switch argument := m.Argument.(type) {
+	case DSParameter:
+		return c.SupportsDSP(argument)
+	case CumulationPeriod:
+		return c.SupportsEnergy(argument)
+	case Counter:
+		return c.SupportsCounter(argument)
+	}
+	return true
+}
+
+// args returns the arguments of the command that reads the metric
+func (m *Metric) args() []Argument {
+	if m.Argument == nil {
+		return nil
+	}
+	return []Argument{m.Argument}
+}
+
+// threePhase are the models feeding all three phases of the grid
+var threePhase = []Product{Product3PhaseInterface, Product50kWModule, Product10kW, Product12kW}
+
+var (
+	metrics     []*Metric
+	metricNames = map[string]*Metric{}
+)
+
+func init() {
+	for _, parameter := range dsParameterValues {
+		m := &Metric{
+			Name:     parameter.Name(),
+			Text:     parameter.String(),
+			Command:  GetDSP,
+			Argument: parameter,
+			Unit:     parameter.Unit(),
+			Kind:     MetricGauge,
+		}
+		switch parameter {
+		case DSPGridVoltagePhaseR, DSPGridVoltagePhaseS, DSPGridVoltagePhaseT,
+			DSPGridCurrentPhaseR, DSPGridCurrentPhaseS, DSPGridCurrentPhaseT,
+			DSPFrequencyPhaseR, DSPFrequencyPhaseS, DSPFrequencyPhaseT:
+			m.Models = threePhase
+		}
+		addMetric(m)
+	}
+
+	for _, period := range cumulationPeriodValues {
+		addMetric(&Metric{
+			Name:     "energy_" + period.Name(),
+			Text:     period.String() + " Energy",
+			Command:  GetCumulatedEnergy,
+			Argument: period,
+			Unit:     UnitWattHours,
+			Kind:     MetricCounter,
+		})
+	}
+
+	for _, counter := range counterValues {
+		if counter == CounterReset {
+			continue
+		}
+		addMetric(&Metric{
+			Name:     "run_time_" + counter.Name(),
+			Text:     counter.String(),
+			Command:  GetCounters,
+			Argument: counter,
+			Unit:     UnitSeconds,
+			Kind:     MetricCounter,
+		})
+	}
+
+	addMetric(&Metric{
+		Name:    "last_10_sec_energy",
+		Text:    "Last 10 Seconds Energy",
+		Command: GetLast10SecEnergy,
+		Unit:    UnitJoules,
+		Kind:    MetricGauge,
+	})
+}
+
+func addMetric(m *Metric) {
+	metrics = append(metrics, m)
+	metricNames[m.Name] = m
+}
+
+// LookupMetric returns the metric with the given name
+func LookupMetric(name string) (*Metric, bool) {
+	m, ok := metricNames[name]
+	return m, ok
+}
+
+// Metrics returns every known metric, DSP values first followed by cumulated
+// energy, run time counters and the energy exported in the last 10 seconds
+func Metrics() []*Metric {
+	return append([]*Metric(nil), metrics...)
+}
+
+// Read returns the current value of the metric, in the unit of the metric
+func (i *Inverter) Read(ctx context.Context, m *Metric) (Measurement, error) {
+	switch m.Command {
+	case GetDSP, GetCumulatedEnergy, GetLast10SecEnergy:
+	case GetCounters:
+		if m.Argument == CounterReset {
+			return Measurement{}, &ArgumentError{Command: m.Command, Got: m.Argument, Err: ErrNotMeasurement}
+		}
+	default:
+		return Measurement{}, &ArgumentError{Command: m.Command, Got: m.Argument, Err: ErrNotMeasurement}
+	}
+
+	value, err := i.QueryContext(ctx, m.Command, m.args()...)
+	if err != nil {
+		return Measurement{}, err
+	}
+
+	switch v := value.(type) {
+	case Measurement:
+		return v, nil
+	case WattHours:
+		return Measurement{Value: float64(v), Unit: UnitWattHours}, nil
+	case Joules:
+		return Measurement{Value: float64(v), Unit: UnitJoules}, nil
+	case time.Duration:
+		return Measurement{Value: v.Seconds(), Unit: UnitSeconds}, nil
+	}
+	return Measurement{}, &ArgumentError{Command: m.Command, Got: m.Argument, Err: ErrNotMeasurement}
+}
@@ -0,0 +1,118 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package aurora_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/freman/go-aurora"
+	"github.com/freman/go-aurora/aurorasim"
+)
+
+func TestMetrics(t *testing.T) {
+	names := map[string]bool{}
+	for _, m := range aurora.Metrics() {
+		if names[m.Name] {
+			t.Errorf("Duplicate metric %s", m.Name)
+		}
+		names[m.Name] = true
+
+		if got, ok := aurora.LookupMetric(m.Name); !ok || got != m {
+			t.Errorf("Expected to look up %s", m.Name)
+		}
+		if m.Command == aurora.GetCounters && m.Argument == aurora.CounterReset {
+			t.Error("Expected no metric for resetting the partial counters")
+		}
+	}
+
+	tests := []struct {
+		name     string
+		command  aurora.Command
+		argument aurora.Argument
+		unit     aurora.UnitOfMeasure
+		kind     aurora.MetricKind
+	}{
+		{"grid_power", aurora.GetDSP, aurora.DSPGridPower, aurora.UnitWatts, aurora.MetricGauge},
+		{"energy_last_7_days", aurora.GetCumulatedEnergy, aurora.CumulatedLast7Days, aurora.UnitWattHours, aurora.MetricCounter},
+		{"run_time_grid", aurora.GetCounters, aurora.CounterGrid, aurora.UnitSeconds, aurora.MetricCounter},
+		{"last_10_sec_energy", aurora.GetLast10SecEnergy, nil, aurora.UnitJoules, aurora.MetricGauge},
+	}
+	for _, test := range tests {
+		m, ok := aurora.LookupMetric(test.name)
+		if !ok {
+			t.Errorf("Expected metric %s", test.name)
+			continue
+		}
+		if m.Command != test.command || m.Argument != test.argument || m.Unit != test.unit || m.Kind != test.kind {
+			t.Errorf("Unexpected %s: %+v", test.name, m)
+		}
+	}
+
+	if _, ok := aurora.LookupMetric("run_time_reset"); ok {
+		t.Error("Expected no run_time_reset metric")
+	}
+}
+
+func TestMetricSupportedBy(t *testing.T) {
+	phase, _ := aurora.LookupMetric("grid_voltage_phase_r")
+	power, _ := aurora.LookupMetric("grid_power")
+	joules, _ := aurora.LookupMetric("last_10_sec_energy")
+
+	caps := &aurora.Capabilities{
+		Version: aurora.Version{Model: aurora.Product3_6kWOutdoor},
+		DSP:     []aurora.DSParameter{aurora.DSPGridPower, aurora.DSPGridVoltagePhaseR},
+	}
+	if !power.SupportedBy(caps) || !joules.SupportedBy(caps) {
+		t.Error("Expected grid power and last 10 seconds energy to be supported")
+	}
+	if phase.SupportedBy(caps) {
+		t.Error("Expected a single phase model not to support a phase voltage")
+	}
+
+	caps.Version.Model = aurora.Product10kW
+	if !phase.SupportedBy(caps) {
+		t.Error("Expected a three phase model to support a phase voltage")
+	}
+
+	var unknown *aurora.Capabilities
+	if !phase.SupportedBy(unknown) {
+		t.Error("Expected nil capabilities to support everything")
+	}
+}
+
+func TestRead(t *testing.T) {
+	sim := aurorasim.NewInverter(2)
+	sim.SetDSP(aurora.DSPGridPower, 960.5)
+	sim.SetEnergy(aurora.CumulatedDaily, 12345)
+	sim.SetCounter(aurora.CounterGrid, 3600)
+	sim.Joules = 42
+	i := simulatedInverter(t, sim)
+
+	for name, expected := range map[string]aurora.Measurement{
+		"grid_power":         {Value: 960.5, Unit: aurora.UnitWatts},
+		"energy_daily":       {Value: 12345, Unit: aurora.UnitWattHours},
+		"run_time_grid":      {Value: 3600, Unit: aurora.UnitSeconds},
+		"last_10_sec_energy": {Value: 42, Unit: aurora.UnitJoules},
+	} {
+		m, _ := aurora.LookupMetric(name)
+		value, err := i.Read(context.Background(), m)
+		if err != nil {
+			t.Errorf("Reading %s: %v", name, err)
+		} else if value != expected {
+			t.Errorf("Expected %s of %v got %v", name, expected, value)
+		}
+	}
+
+	for _, m := range []*aurora.Metric{
+		{Name: "reset", Command: aurora.GetCounters, Argument: aurora.CounterReset},
+		{Name: "time", Command: aurora.SetTime},
+	} {
+		if _, err := i.Read(context.Background(), m); !errors.Is(err, aurora.ErrNotMeasurement) {
+			t.Errorf("Expected %v reading %s got %v", aurora.ErrNotMeasurement, m, err)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package aurora_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/freman/go-aurora"
+	"github.com/freman/go-aurora/transport"
+)
+
+// replayInverter returns an inverter talking to a capture from testdata
+func replayInverter(t *testing.T, name string) (*aurora.Inverter, *transport.Replayer) {
+	replayer, err := transport.ReplayFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &aurora.Inverter{Conn: replayer, Address: 2}, replayer
+}
+
+func TestReplayAuroraSim(t *testing.T) {
+	i, replayer := replayInverter(t, "aurorasim.jsonl")
+
+	version, err := i.Version()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version.Model != aurora.Product3_6kWOutdoor {
+		t.Errorf("Expected %s got %s", aurora.Product3_6kWOutdoor, version.Model)
+	}
+
+	if serial, err := i.SerialNumber(); err != nil || serial != "134512" {
+		t.Errorf("Expected %s got %s (%v)", "134512", serial, err)
+	}
+
+	state, err := i.State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Global != aurora.GSRun || state.Channel1 != aurora.DCDCMPPT {
+		t.Errorf("Unexpected state %s", state)
+	}
+
+	if power, err := i.GridPower(); err != nil || power != 2875.5 {
+		t.Errorf("Expected %f got %f (%v)", 2875.5, power, err)
+	}
+
+	if energy, err := i.DailyEnergy(); err != nil || energy != 12345 {
+		t.Errorf("Expected %d got %d (%v)", 12345, energy, err)
+	}
+
+	alarms, err := i.Last4Alarms()
+	expected := []aurora.AlarmState{aurora.AlarmNone, aurora.AlarmGridFail, aurora.AlarmGridOF, aurora.AlarmGroundFault18}
+	if err != nil || !reflect.DeepEqual(expected, alarms) {
+		t.Errorf("Expected %v got %v (%v)", expected, alarms, err)
+	}
+
+	if clock, err := i.GetTime(); err != nil || !clock.Equal(time.Unix(1465000000, 0)) {
+		t.Errorf("Expected %v got %v (%v)", time.Unix(1465000000, 0), clock, err)
+	}
+
+	if _, err := i.GetDSPData(aurora.DSPFan1Speed); !errors.Is(err, aurora.TSVariableDoesNotExist) {
+		t.Errorf("Expected %v got %v", aurora.TSVariableDoesNotExist, err)
+	}
+
+	if remaining := replayer.Remaining(); remaining != 0 {
+		t.Errorf("Expected capture to be exhausted, %d events remain", remaining)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package aurora
+
+import (
+	"context"
+	"time"
+)
+
+// RetryPolicy describes how failed exchanges with an inverter are retried.
+// Requests that change state in the inverter, setting the time or resetting the
+// partial counter, are never retried.
+type RetryPolicy struct {
+	// Attempts is the maximum number of attempts made, including the first
+	Attempts int
+
+	// Backoff is the schedule of delays between attempts, the last of which is
+	// repeated should there be more attempts than delays
+	Backoff []time.Duration
+
+	// Retryable decides if a failed exchange is worth retrying, when nil
+	// Temporary is used
+	Retryable func(err error) bool
+}
+
+// DefaultRetryPolicy is used by a Bus created with NewBus, it retries temporary
+// failures twice backing off a little further each time
+var DefaultRetryPolicy = &RetryPolicy{
+	Attempts: 3,
+	Backoff:  []time.Duration{10 * time.Millisecond, 50 * time.Millisecond},
+}
+
+// Delay returns the delay before the given attempt, attempts count from 1
+func (p *RetryPolicy) Delay(attempt int) time.Duration {
+	if len(p.Backoff) == 0 || attempt < 2 {
+		return 0
+	}
+	if attempt-2 < len(p.Backoff) {
+		return p.Backoff[attempt-2]
+	}
+	return p.Backoff[len(p.Backoff)-1]
+}
+
+// wait returns true once it's time for another attempt, or false if err is not
+// worth retrying, the attempts are exhausted or the context is done first
+func (p *RetryPolicy) wait(ctx context.Context, attempt int, err error) bool {
+	if p == nil || attempt >= p.Attempts {
+		return false
+	}
+
+	retryable := p.Retryable
+	if retryable == nil {
+		retryable = Temporary
+	}
+	if !retryable(err) || ctx.Err() != nil {
+		return false
+	}
+
+	delay := p.Delay(attempt + 1)
+	if delay == 0 {
+		return true
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package aurora_test
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/freman/go-aurora"
+)
+
+// mockRespond answers a request with the given payload, corrupting the CRC if asked to
+func mockRespond(t *testing.T, conn io.ReadWriter, res []byte, badCRC bool) {
+	tmp := make([]byte, 10)
+	if _, err := io.ReadFull(conn, tmp); err != nil {
+		t.Error(err)
+		return
+	}
+
+	crc := aurora.CRC(res)
+	if badCRC {
+		crc++
+	}
+	binary.Write(conn, binary.LittleEndian, res)
+	binary.Write(conn, binary.LittleEndian, crc)
+}
+
+func TestRetryTemporary(t *testing.T) {
+	// Needs deadlines to give up resynchronising after the bad CRC
+	ttys0, ttys1 := net.Pipe()
+	defer ttys1.Close()
+	bus := aurora.NewBus(ttys0)
+	bus.Retry = &aurora.RetryPolicy{Attempts: 3, Backoff: []time.Duration{time.Millisecond}}
+	i := bus.Inverter(2)
+
+	go func() {
+		mockRespond(t, ttys1, []byte{0, 6, 0, 0, 0x30, 0x39}, true)
+		mockRespond(t, ttys1, []byte{byte(aurora.TSVariableNotAvailable), 6, 0, 0, 0, 0}, false)
+		mockRespond(t, ttys1, []byte{0, 6, 0, 0, 0x30, 0x39}, false)
+	}()
+
+	energy, err := i.DailyEnergy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if energy != 12345 {
+		t.Errorf("Expected %d got %d", 12345, energy)
+	}
+
+	expected := aurora.Stats{Requests: 1, Attempts: 3, Retries: 2}
+	if stats := i.Stats(); stats != expected {
+		t.Errorf("Expected %+v got %+v", expected, stats)
+	}
+}
+
+func TestRetryExhausted(t *testing.T) {
+	ttys0, ttys1 := mockSerialPair()
+	i := &aurora.Inverter{Conn: ttys0, Address: 2, Retry: &aurora.RetryPolicy{Attempts: 2}}
+
+	go func() {
+		mockRespond(t, ttys1, []byte{0, 6, 0, 0, 0, 0}, true)
+		mockRespond(t, ttys1, []byte{0, 6, 0, 0, 0, 0}, true)
+	}()
+
+	_, err := i.DailyEnergy()
+	if !errors.Is(err, aurora.ErrCRCFailure) {
+		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
+	}
+
+	var ierr *aurora.InverterError
+	if errors.As(err, &ierr) && ierr.Attempts != 2 {
+		t.Errorf("Expected %d attempts got %d", 2, ierr.Attempts)
+	}
+
+	expected := aurora.Stats{Requests: 1, Attempts: 2, Retries: 1, Failures: 1}
+	if stats := i.Stats(); stats != expected {
+		t.Errorf("Expected %+v got %+v", expected, stats)
+	}
+}
+
+func TestRetryFailFast(t *testing.T) {
+	ttys0, ttys1 := mockSerialPair()
+	i := &aurora.Inverter{Conn: ttys0, Address: 2, Retry: aurora.DefaultRetryPolicy}
+
+	go mockRespond(t, ttys1, []byte{byte(aurora.TSVariableDoesNotExist), 6, 0, 0, 0, 0}, false)
+
+	_, err := i.GetDSPData(aurora.DSPGridVoltagePhaseT)
+	if !errors.Is(err, aurora.TSVariableDoesNotExist) {
+		t.Errorf("Expected %v got %v", aurora.TSVariableDoesNotExist, err)
+	}
+
+	if stats := i.Stats(); stats.Attempts != 1 {
+		t.Errorf("Expected %d attempts got %d", 1, stats.Attempts)
+	}
+}
+
+func TestRetryContextDone(t *testing.T) {
+	ttys0, ttys1 := mockSerialPair()
+	i := &aurora.Inverter{Conn: ttys0, Address: 2, Retry: &aurora.RetryPolicy{Attempts: 3, Backoff: []time.Duration{time.Hour}}}
+
+	go mockRespond(t, ttys1, []byte{0, 6, 0, 0, 0, 0}, true)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// Should give up waiting to retry rather than sleep for an hour
+	_, err := i.DailyEnergyContext(ctx)
+	if !errors.Is(err, aurora.ErrCRCFailure) {
+		t.Errorf("Expected %v got %v", aurora.ErrCRCFailure, err)
+	}
+}
+
+// timeoutConn times out every read, counting the requests written to it
+type timeoutConn struct {
+	requests int
+}
+
+func (c *timeoutConn) Read(p []byte) (int, error) {
+	return 0, os.ErrDeadlineExceeded
+}
+
+func (c *timeoutConn) Write(p []byte) (int, error) {
+	c.requests++
+	return len(p), nil
+}
+
+func TestRetryWritesOnce(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Call     func(i *aurora.Inverter) error
+		Requests int
+	}{
+		{Name: "GetTime", Requests: 3, Call: func(i *aurora.Inverter) error {
+			_, err := i.GetTime()
+			return err
+		}},
+		{Name: "SetTime", Requests: 1, Call: func(i *aurora.Inverter) error {
+			return i.SetTime(time.Now())
+		}},
+		{Name: "ResetRunTime", Requests: 1, Call: func(i *aurora.Inverter) error {
+			return i.ResetRunTime()
+		}},
+	}
+
+	for _, test := range tests {
+		conn := &timeoutConn{}
+		i := &aurora.Inverter{Conn: conn, Address: 2, Retry: aurora.DefaultRetryPolicy}
+
+		err := test.Call(i)
+		if !errors.Is(err, aurora.ErrTimeout) {
+			t.Errorf("%s: Expected %v got %v", test.Name, aurora.ErrTimeout, err)
+		}
+		if conn.requests != test.Requests {
+			t.Errorf("%s: Expected %d requests got %d", test.Name, test.Requests, conn.requests)
+		}
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	p := &aurora.RetryPolicy{Backoff: []time.Duration{time.Millisecond, time.Second}}
+	tests := []struct {
+		Attempt int
+		Expect  time.Duration
+	}{
+		{Attempt: 1, Expect: 0},
+		{Attempt: 2, Expect: time.Millisecond},
+		{Attempt: 3, Expect: time.Second},
+		{Attempt: 9, Expect: time.Second},
+	}
+
+	for _, test := range tests {
+		if delay := p.Delay(test.Attempt); delay != test.Expect {
+			t.Errorf("Delay(%d) = %v, expected %v", test.Attempt, delay, test.Expect)
+		}
+	}
+}
+
+func TestTemporary(t *testing.T) {
+	tests := []struct {
+		Err    error
+		Expect bool
+	}{
+		{Err: aurora.ErrCRCFailure, Expect: true},
+		{Err: aurora.ErrTimeout, Expect: true},
+		{Err: aurora.TSVariableNotAvailable, Expect: true},
+		{Err: aurora.TSMicroError, Expect: true},
+		{Err: aurora.TSCommandNotImplemented, Expect: false},
+		{Err: aurora.TSVariableDoesNotExist, Expect: false},
+		{Err: context.Canceled, Expect: false},
+		{Err: &aurora.InverterError{Err: aurora.TSVariableNotAvailable}, Expect: true},
+	}
+
+	for _, test := range tests {
+		if temporary := aurora.Temporary(test.Err); temporary != test.Expect {
+			t.Errorf("Temporary(%v) = %t, expected %t", test.Err, temporary, test.Expect)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package aurora
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultScanTimeout is how long Scan waits for a response from each address
+// when the ScanTimeout of the bus is zero
+const DefaultScanTimeout = 250 * time.Millisecond
+
+// Unit is an inverter found on the bus by Scan
+type Unit struct {
+	Address      byte
+	Version      *Version
+	SerialNumber string
+	Firmware     string
+}
+
+// String returns the unit as an easy to read string
+func (u *Unit) String() string {
+	return fmt.Sprintf("Address: %d, Serial: %s, Firmware: %s, %s", u.Address, u.SerialNumber, u.Firmware, u.Version)
+}
+
+// scanPolicy makes a single attempt, an address that doesn't respond is empty
+var scanPolicy = &RetryPolicy{Attempts: 1}
+
+// Scan probes each address from..to inclusive with a GetVersion request,
+// returning the units that respond along with their serial number and firmware.
+// Each request waits ScanTimeout, so scanning the whole bus takes a while. Should
+// the context be done part way through the units found so far are returned
+// along with the context error.
+//
+// ScanTimeout is enforced with read deadlines, so the Conn must support them,
+// as a net.Conn or a serial port opened by the transport package does. Without
+// them ErrNoDeadline is returned rather than blocking on the first empty address.
+func (b *Bus) Scan(ctx context.Context, from, to byte) ([]*Unit, error) {
+	if _, ok := b.Conn.(readDeadliner); !ok {
+		return nil, ErrNoDeadline
+	}
+
+	timeout := b.ScanTimeout
+	if timeout == 0 {
+		timeout = DefaultScanTimeout
+	}
+
+	var units []*Unit
+	for address := int(from); address <= int(to); address++ {
+		if err := ctx.Err(); err != nil {
+			return units, err
+		}
+
+		inverter := b.Inverter(byte(address))
+		inverter.Retry = scanPolicy
+
+		unit, err := probe(ctx, inverter, timeout)
+		if err != nil {
+			continue
+		}
+		units = append(units, unit)
+	}
+
+	return units, nil
+}
+
+// probe asks the inverter for its version, if that succeeds it's asked for the
+// rest of the details which are left empty should it fail to provide them
+func probe(ctx context.Context, inverter *Inverter, timeout time.Duration) (*Unit, error) {
+	pctx, cancel := context.WithTimeout(ctx, timeout)
+	version, err := inverter.VersionContext(pctx)
+	cancel()
+
+	var ierr *InverterError
+	if err != nil && !(errors.As(err, &ierr) && ierr.Frame != nil) {
+		// Nobody home, anything that sent a frame back is at least there
+		return nil, err
+	}
+
+	unit := &Unit{
+		Address: inverter.Address,
+		Version: version,
+	}
+
+	pctx, cancel = context.WithTimeout(ctx, timeout)
+	unit.SerialNumber, _ = inverter.SerialNumberContext(pctx)
+	cancel()
+
+	pctx, cancel = context.WithTimeout(ctx, timeout)
+	unit.Firmware, _ = inverter.FirmwareVersionContext(pctx)
+	cancel()
+
+	return unit, nil
+}
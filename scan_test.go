@@ -0,0 +1,112 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package aurora_test
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/freman/go-aurora"
+)
+
+// mockPopulatedBus answers requests for the given addresses, ignoring the rest
+func mockPopulatedBus(conn io.ReadWriter, addresses ...byte) {
+	for {
+		tmp := make([]byte, 10)
+		if _, err := io.ReadFull(conn, tmp); err != nil {
+			return
+		}
+
+		present := false
+		for _, address := range addresses {
+			present = present || tmp[0] == address
+		}
+		if !present {
+			continue
+		}
+
+		var res []byte
+		switch aurora.Command(tmp[1]) {
+		case aurora.GetVersion:
+			res = []byte{0, 6, 'I', 'K', 'N', 'N'}
+		case aurora.GetSerialNumber:
+			res = []byte{'0', '0', '0', '1', '2', tmp[0] + '0'}
+		case aurora.GetFirmwareVersion:
+			res = []byte{0, 6, 'C', '1', '2', '3'}
+		default:
+			res = []byte{byte(aurora.TSCommandNotImplemented), 6, 0, 0, 0, 0}
+		}
+		binary.Write(conn, binary.LittleEndian, res)
+		binary.Write(conn, binary.LittleEndian, aurora.CRC(res))
+	}
+}
+
+func TestBusScan(t *testing.T) {
+	ttys0, ttys1 := net.Pipe()
+	defer ttys1.Close()
+	bus := aurora.NewBus(ttys0)
+	bus.ScanTimeout = 20 * time.Millisecond
+
+	go mockPopulatedBus(ttys1, 2, 5)
+
+	units, err := bus.Scan(context.Background(), 1, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	version := &aurora.Version{
+		Model:       aurora.Product3_6kWIndoor,
+		Regulation:  aurora.ProductSpecAS4777,
+		Transformer: aurora.InverterTransformerless,
+		Type:        aurora.InputPhotovoltaic,
+	}
+	expected := []*aurora.Unit{
+		{Address: 2, Version: version, SerialNumber: "000122", Firmware: "C.1.2.3"},
+		{Address: 5, Version: version, SerialNumber: "000125", Firmware: "C.1.2.3"},
+	}
+
+	if !reflect.DeepEqual(expected, units) {
+		t.Errorf("Expected %v got %v", expected, units)
+	}
+}
+
+func TestBusScanCancel(t *testing.T) {
+	ttys0, ttys1 := net.Pipe()
+	defer ttys1.Close()
+	bus := aurora.NewBus(ttys0)
+
+	go mockPopulatedBus(ttys1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	units, err := bus.Scan(ctx, 0, 255)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected %v got %v", context.DeadlineExceeded, err)
+	}
+	if len(units) != 0 {
+		t.Errorf("Expected no units got %v", units)
+	}
+}
+
+func TestBusScanNoDeadline(t *testing.T) {
+	ttys0, ttys1 := mockSerialPair()
+	bus := aurora.NewBus(ttys0)
+
+	go mockPopulatedBus(ttys1, 2)
+
+	units, err := bus.Scan(context.Background(), 1, 3)
+	if err != aurora.ErrNoDeadline {
+		t.Errorf("Expected %v got %v", aurora.ErrNoDeadline, err)
+	}
+	if len(units) != 0 {
+		t.Errorf("Expected no units got %v", units)
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package aurora
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// SnapshotOptions tunes what Snapshot reads
+type SnapshotOptions struct {
+	// Capabilities leaves out whatever the inverter doesn't support, when nil
+	// everything known is asked for
+	Capabilities *Capabilities
+
+	// DSP lists the parameters to read, when empty every known parameter is
+	DSP []DSParameter
+}
+
+// Snapshot holds every measurement read from an inverter in one go. Each
+// reading carries the error encountered reading it, so one failure doesn't
+// spoil the rest.
+type Snapshot struct {
+	Address  byte                               `json:"address"`
+	Time     time.Time                          `json:"time"` // When reading started
+	State    StateReading                       `json:"state"`
+	DSP      map[DSParameter]FloatReading       `json:"dsp"`
+	Energy   map[CumulationPeriod]EnergyReading `json:"energy"`
+	Counters map[Counter]DurationReading        `json:"counters"` // Run times
+	Joules   JoulesReading                      `json:"joules"`   // Energy exported in the last 10 seconds
+}
+
+// StateReading is the state of the inverter as read for a Snapshot
+type StateReading struct {
+	Value *State
+	Err   error
+}
+
+// FloatReading is a DSP value as read for a Snapshot
+type FloatReading struct {
+	Value float32
+	Unit  UnitOfMeasure
+	Err   error
+}
+
+// EnergyReading is a cumulated energy as read for a Snapshot
+type EnergyReading struct {
+	Value WattHours
+	Err   error
+}
+
+// DurationReading is a counter as read for a Snapshot
+type DurationReading struct {
+	Value time.Duration
+	Err   error
+}
+
+// JoulesReading is the energy exported in the last 10 seconds as read for a Snapshot
+type JoulesReading struct {
+	Value Joules
+	Err   error
+}
+
+// Valid returns true if the value was read
+func (r StateReading) Valid() bool { return r.Err == nil }
+
+// Valid returns true if the value was read
+func (r FloatReading) Valid() bool { return r.Err == nil }
+
+// Valid returns true if the value was read
+func (r EnergyReading) Valid() bool { return r.Err == nil }
+
+// Valid returns true if the value was read
+func (r DurationReading) Valid() bool { return r.Err == nil }
+
+// Valid returns true if the value was read
+func (r JoulesReading) Valid() bool { return r.Err == nil }
+
+// MarshalJSON implements json.Marshaler
+func (r StateReading) MarshalJSON() ([]byte, error) { return marshalReading(r.Value, UnitNone, r.Err) }
+
+// MarshalJSON implements json.Marshaler
+func (r FloatReading) MarshalJSON() ([]byte, error) { return marshalReading(r.Value, r.Unit, r.Err) }
+
+// MarshalJSON implements json.Marshaler
+func (r EnergyReading) MarshalJSON() ([]byte, error) {
+	return marshalReading(uint32(r.Value), UnitWattHours, r.Err)
+}
+
+// MarshalJSON implements json.Marshaler, the duration is in seconds
+func (r DurationReading) MarshalJSON() ([]byte, error) {
+	return marshalReading(int64(r.Value/time.Second), UnitSeconds, r.Err)
+}
+
+// MarshalJSON implements json.Marshaler
+func (r JoulesReading) MarshalJSON() ([]byte, error) {
+	return marshalReading(uint16(r.Value), UnitJoules, r.Err)
+}
+
+// marshalReading encodes a reading as {"value": ..., "unit": "..."} or
+// {"error": "..."}, the unit is left out if there isn't one
+func marshalReading(value interface{}, unit UnitOfMeasure, err error) ([]byte, error) {
+	if err != nil {
+		return json.Marshal(struct {
+			Error string `json:"error"`
+		}{err.Error()})
+	}
+	return json.Marshal(struct {
+		Value interface{}   `json:"value"`
+		Unit  UnitOfMeasure `json:"unit,omitempty"`
+	}{value, unit})
+}
+
+// Snapshot reads the state, DSP values, cumulated energy for every period, the
+// run time counters and the energy exported in the last 10 seconds. Readings
+// that fail carry their error while the rest carry on, should the context be
+// done those yet to be read fail with its error. opts may be nil.
+func (i *Inverter) Snapshot(ctx context.Context, opts *SnapshotOptions) *Snapshot {
+	if opts == nil {
+		opts = &SnapshotOptions{}
+	}
+	caps := opts.Capabilities
+
+	s := &Snapshot{
+		Address:  i.Address,
+		Time:     time.Now(),
+		DSP:      map[DSParameter]FloatReading{},
+		Energy:   map[CumulationPeriod]EnergyReading{},
+		Counters: map[Counter]DurationReading{},
+	}
+
+	s.State.Value, s.State.Err = i.StateContext(ctx)
+
+	parameters := opts.DSP
+	if len(parameters) == 0 {
+		parameters = dsParameterValues
+	}
+	for _, parameter := range parameters {
+		if !caps.SupportsDSP(parameter) {
+			continue
+		}
+		r := FloatReading{Unit: parameter.Unit()}
+		r.Value, r.Err = i.GetDSPDataContext(ctx, parameter)
+		s.DSP[parameter] = r
+	}
+
+	for _, period := range cumulationPeriodValues {
+		if !caps.SupportsEnergy(period) {
+			continue
+		}
+		var r EnergyReading
+		r.Value, r.Err = i.GetCumulatedEnergyContext(ctx, period)
+		s.Energy[period] = r
+	}
+
+	for _, counter := range counterValues {
+		if counter == CounterReset || !caps.SupportsCounter(counter) {
+			continue
+		}
+		var r DurationReading
+		r.Value, r.Err = i.getDuration(ctx, counter)
+		s.Counters[counter] = r
+	}
+
+	s.Joules.Value, s.Joules.Err = i.JoulesContext(ctx)
+
+	return s
+}
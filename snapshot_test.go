@@ -0,0 +1,110 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package aurora_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/freman/go-aurora"
+	"github.com/freman/go-aurora/aurorasim"
+)
+
+func TestSnapshot(t *testing.T) {
+	sim := aurorasim.NewInverter(2)
+	sim.SetEnergy(aurora.CumulatedDaily, 12345)
+	sim.SetCounter(aurora.CounterTotal, 3600)
+	sim.Joules = 42
+	sim.Fail(aurora.GetDSP, aurora.TSVariableNotAvailable, 0)
+	i := simulatedInverter(t, sim)
+	i.Retry = &aurora.RetryPolicy{Attempts: 1}
+
+	s := i.Snapshot(context.Background(), &aurora.SnapshotOptions{
+		DSP: []aurora.DSParameter{aurora.DSPGridPower, aurora.DSPFan1Speed},
+	})
+
+	if s.Address != 2 || s.Time.IsZero() {
+		t.Errorf("Unexpected address %d and time %v", s.Address, s.Time)
+	}
+	if !s.State.Valid() || s.State.Value.Global != aurora.GSRun {
+		t.Errorf("Unexpected state %v (%v)", s.State.Value, s.State.Err)
+	}
+
+	// Every DSP reading failed but the rest carried on
+	if len(s.DSP) != 2 {
+		t.Errorf("Expected %d DSP readings got %d", 2, len(s.DSP))
+	}
+	for parameter, r := range s.DSP {
+		if r.Valid() || !errors.Is(r.Err, aurora.TSVariableNotAvailable) {
+			t.Errorf("Expected %v reading %s got %v", aurora.TSVariableNotAvailable, parameter, r.Err)
+		}
+	}
+
+	if r := s.Energy[aurora.CumulatedDaily]; !r.Valid() || r.Value != 12345 {
+		t.Errorf("Expected %d got %d (%v)", 12345, r.Value, r.Err)
+	}
+	if r := s.Counters[aurora.CounterTotal]; !r.Valid() || r.Value != time.Hour {
+		t.Errorf("Expected %v got %v (%v)", time.Hour, r.Value, r.Err)
+	}
+	if _, ok := s.Counters[aurora.CounterReset]; ok {
+		t.Error("Expected the reset counter to be left alone")
+	}
+	if !s.Joules.Valid() || s.Joules.Value != 42 {
+		t.Errorf("Expected %d got %d (%v)", 42, s.Joules.Value, s.Joules.Err)
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expect := range []string{`"Global":"run"`, `"joules":{"value":42,"unit":"J"}`, `"daily":{"value":12345,"unit":"Wh"}`, `"total":{"value":3600,"unit":"s"}`, `"grid_power":{"error":"inverter 2: Measure Request to the DSP: The variable is not available, retry"}`} {
+		if !strings.Contains(string(data), expect) {
+			t.Errorf("Expected %s in %s", expect, data)
+		}
+	}
+}
+
+func TestSnapshotCapabilities(t *testing.T) {
+	sim := aurorasim.NewInverter(2)
+	i := simulatedInverter(t, sim)
+
+	caps := &aurora.Capabilities{
+		DSP:    []aurora.DSParameter{aurora.DSPGridPower},
+		Energy: []aurora.CumulationPeriod{aurora.CumulatedTotal},
+	}
+	s := i.Snapshot(context.Background(), &aurora.SnapshotOptions{Capabilities: caps})
+
+	if len(s.DSP) != 1 || !s.DSP[aurora.DSPGridPower].Valid() {
+		t.Errorf("Expected only %s got %v", aurora.DSPGridPower, s.DSP)
+	}
+	if unit := s.DSP[aurora.DSPGridPower].Unit; unit != aurora.UnitWatts {
+		t.Errorf("Expected %v got %v", aurora.UnitWatts, unit)
+	}
+	if len(s.Energy) != 1 || !s.Energy[aurora.CumulatedTotal].Valid() {
+		t.Errorf("Expected only %s got %v", aurora.CumulatedTotal, s.Energy)
+	}
+	if len(s.Counters) != 0 {
+		t.Errorf("Expected no counters got %v", s.Counters)
+	}
+}
+
+func TestSnapshotCancelled(t *testing.T) {
+	i := simulatedInverter(t, aurorasim.NewInverter(2))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	s := i.Snapshot(ctx, nil)
+	if !errors.Is(s.State.Err, context.Canceled) || !errors.Is(s.Joules.Err, context.Canceled) {
+		t.Errorf("Expected %v got %v and %v", context.Canceled, s.State.Err, s.Joules.Err)
+	}
+	if r := s.DSP[aurora.DSPGridPower]; r.Valid() {
+		t.Errorf("Expected %s to fail", aurora.DSPGridPower)
+	}
+}
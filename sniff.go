@@ -0,0 +1,154 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package aurora
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Sniffer passively listens to a bus polled by another master, pairing each
+// request it sees with the response. It never transmits.
+type Sniffer struct {
+	// Now returns the time exchanges are stamped with, defaults to time.Now
+	Now func() time.Time
+
+	// DiscardedBytes counts bytes that weren't part of any valid frame
+	DiscardedBytes uint64
+
+	r       *bufio.Reader
+	window  []byte
+	pending *RequestFrame
+}
+
+// Exchange is a request seen on the bus paired with its response
+type Exchange struct {
+	Time     time.Time      // When the exchange completed
+	Request  RequestFrame   // The request
+	Response *ResponseFrame // The response, nil if there was none
+	Value    interface{}    // The decoded response, see ResponseFrame.Decode
+	Err      error          // The transmission state if not TSOk, or ErrTimeout if there was no response
+}
+
+// NewSniffer returns a sniffer reading from the given connection
+func NewSniffer(conn io.Reader) *Sniffer {
+	return &Sniffer{r: bufio.NewReader(conn)}
+}
+
+// Next blocks until the next exchange has been seen on the bus, an unanswered
+// request is only returned once the next request is seen. The error is that of
+// reading from the connection, errors in the exchange are in Exchange.Err.
+func (s *Sniffer) Next() (*Exchange, error) {
+	for {
+		if exchange := s.match(); exchange != nil {
+			return exchange, nil
+		}
+
+		b, err := s.r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		s.window = append(s.window, b)
+	}
+}
+
+// match looks for frames in the window, returning any completed exchange
+func (s *Sniffer) match() *Exchange {
+	for {
+		if s.pending != nil && len(s.window) >= ResponseFrameSize {
+			var response ResponseFrame
+			if response.UnmarshalBinary(s.window[:ResponseFrameSize]) == nil {
+				s.window = s.window[ResponseFrameSize:]
+				exchange := s.exchange(s.pending, &response)
+				s.pending = nil
+				return exchange
+			}
+		}
+
+		if len(s.window) < RequestFrameSize {
+			return nil
+		}
+
+		var request RequestFrame
+		if request.UnmarshalBinary(s.window[:RequestFrameSize]) == nil {
+			s.window = s.window[RequestFrameSize:]
+			unanswered := s.pending
+			s.pending = &request
+			if unanswered != nil {
+				return s.exchange(unanswered, nil)
+			}
+			continue
+		}
+
+		// Neither a request nor a response starts here
+		s.window = s.window[1:]
+		s.DiscardedBytes++
+	}
+}
+
+func (s *Sniffer) exchange(request *RequestFrame, response *ResponseFrame) *Exchange {
+	now := time.Now
+	if s.Now != nil {
+		now = s.Now
+	}
+
+	exchange := &Exchange{
+		Time:     now(),
+		Request:  *request,
+		Response: response,
+	}
+
+	if response == nil {
+		exchange.Err = ErrTimeout
+	} else {
+		var args []Argument
+		if arg := exchange.Argument(); arg != nil {
+			args = append(args, arg)
+		}
+		exchange.Value, exchange.Err = response.Decode(request.Command, args...)
+	}
+
+	return exchange
+}
+
+// Address returns the address of the inverter the exchange was with
+func (e *Exchange) Address() byte {
+	return e.Request.Address
+}
+
+// Command returns the command requested
+func (e *Exchange) Command() Command {
+	return e.Request.Command
+}
+
+// Argument returns the argument of a request for a DSP value, cumulated energy
+// or counter as a DSParameter, CumulationPeriod or Counter, or nil for any
+// other request
+func (e *Exchange) Argument() Argument {
+	switch e.Request.Command {
+	case GetDSP:
+		return DSParameter(e.Request.Args[0])
+	case GetCumulatedEnergy:
+		return CumulationPeriod(e.Request.Args[0])
+	case GetCounters:
+		return Counter(e.Request.Args[0])
+	}
+	return nil
+}
+
+// String returns the exchange as an easy to read string
+func (e *Exchange) String() string {
+	what := e.Command().String()
+	if arg := e.Argument(); arg != nil {
+		what = fmt.Sprintf("%s %v", what, arg)
+	}
+
+	if e.Err != nil {
+		return fmt.Sprintf("inverter %d: %s: %v", e.Address(), what, e.Err)
+	}
+	return fmt.Sprintf("inverter %d: %s: %v", e.Address(), what, e.Value)
+}
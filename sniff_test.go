@@ -0,0 +1,104 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package aurora_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/freman/go-aurora"
+)
+
+// wire returns what would be seen on the bus for the given frames
+func wire(frames ...interface{ MarshalBinary() ([]byte, error) }) []byte {
+	buf := new(bytes.Buffer)
+	for _, frame := range frames {
+		data, _ := frame.MarshalBinary()
+		buf.Write(data)
+	}
+	return buf.Bytes()
+}
+
+func TestSniffer(t *testing.T) {
+	stream := new(bytes.Buffer)
+	stream.Write([]byte{0xff, 0x00, 0x13}) // Joined mid conversation
+	stream.Write(wire(
+		aurora.NewRequestFrame(2, aurora.GetDSP, aurora.DSPGridPower),
+		&aurora.ResponseFrame{Payload: [6]byte{0, 6, 0x45, 0x33, 0xb8, 0}},
+		aurora.NewRequestFrame(3, aurora.GetVersion), // Nobody home
+		aurora.NewRequestFrame(2, aurora.GetCumulatedEnergy, aurora.CumulatedDaily),
+		&aurora.ResponseFrame{Payload: [6]byte{0, 6, 0, 0, 0x30, 0x39}},
+		aurora.NewRequestFrame(2, aurora.GetDSP, aurora.DSPFan1Speed),
+		&aurora.ResponseFrame{Payload: [6]byte{byte(aurora.TSVariableDoesNotExist), 6}},
+		aurora.NewRequestFrame(2, aurora.GetState),
+		&aurora.ResponseFrame{Payload: [6]byte{0, 6, 2, 2, 2, 13}},
+	))
+
+	s := aurora.NewSniffer(stream)
+
+	expected := []struct {
+		Address  byte
+		Command  aurora.Command
+		Argument aurora.Argument
+		Value    interface{}
+		Err      error
+	}{
+		{Address: 2, Command: aurora.GetDSP, Argument: aurora.DSPGridPower, Value: aurora.Measurement{Value: 2875.5, Unit: aurora.UnitWatts}},
+		{Address: 3, Command: aurora.GetVersion, Err: aurora.ErrTimeout},
+		{Address: 2, Command: aurora.GetCumulatedEnergy, Argument: aurora.CumulatedDaily, Value: aurora.WattHours(12345)},
+		{Address: 2, Command: aurora.GetDSP, Argument: aurora.DSPFan1Speed, Err: aurora.TSVariableDoesNotExist},
+		{Address: 2, Command: aurora.GetState},
+	}
+
+	for _, expect := range expected {
+		exchange, err := s.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if exchange.Address() != expect.Address || exchange.Command() != expect.Command || exchange.Argument() != expect.Argument {
+			t.Errorf("Expected %d %s %v got %s", expect.Address, expect.Command, expect.Argument, exchange)
+		}
+		if expect.Err != nil {
+			if !errors.Is(exchange.Err, expect.Err) {
+				t.Errorf("Expected %v got %v", expect.Err, exchange.Err)
+			}
+		} else if expect.Value != nil && exchange.Value != expect.Value {
+			t.Errorf("Expected %v got %v", expect.Value, exchange.Value)
+		}
+	}
+
+	if _, err := s.Next(); err != io.EOF {
+		t.Errorf("Expected %v got %v", io.EOF, err)
+	}
+	if s.DiscardedBytes != 3 {
+		t.Errorf("Expected %d discarded bytes got %d", 3, s.DiscardedBytes)
+	}
+}
+
+func TestSnifferState(t *testing.T) {
+	s := aurora.NewSniffer(bytes.NewReader(wire(
+		aurora.NewRequestFrame(2, aurora.GetState),
+		&aurora.ResponseFrame{Payload: [6]byte{0, 6, 2, 2, 2, 13}},
+	)))
+
+	exchange, err := s.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state, ok := exchange.Value.(*aurora.State)
+	if !ok {
+		t.Fatalf("Expected *aurora.State got %T", exchange.Value)
+	}
+	if state.Global != aurora.GSRun || state.Alarm != aurora.AlarmGridFail {
+		t.Errorf("Unexpected state %s", state)
+	}
+	if str := exchange.String(); str != "inverter 2: State Request: "+state.String() {
+		t.Errorf("Unexpected string: %s", str)
+	}
+}
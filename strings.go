@@ -2,17 +2,116 @@
 // Use of this source code is governed by a MIT
 // license that can be found in the LICENSE file.
 
+// Code generated by protogen from protocol.json. DO NOT EDIT.
+
 package aurora
 
-var transmissionStates = map[TransmissionState]string{
-	TSOk: "Ok",
-	TSCommandNotImplemented: "Command is not implemented",
-	TSVariableDoesNotExist:  "Variable does not exist",
-	TSValueOutOfRange:       "Variable value is out of range",
-	TSEEpromNotAccessible:   "EEProm not accessible",
-	TSMicroError:            "Cannot send the command to internal micro",
-	TSNotExecuted:           "Command not executed",
-	TSVariableNotAvailable:  "The variable is not available, retry",
+var commandStrings = map[Command]string{
+	GetState:             "State Request",
+	GetPartNumber:        "P/N Reading",
+	GetVersion:           "Version Reading",
+	GetDSP:               "Measure Request to the DSP",
+	GetSerialNumber:      "Serial Number Reading",
+	GetManufacturingDate: "Manufacturing Week and Year Reading",
+	GetFlags:             "Flags or Switch Reading",
+	GetTime:              "Time/Date Reading",
+	SetTime:              "Time/Date Setting",
+	GetFirmwareVersion:   "Firmware Release Reading",
+	GetLast10SecEnergy:   "Last 10 Seconds Energy Reading",
+	GetConfiguration:     "System Configuration Reading",
+	GetCumulatedEnergy:   "Cumulated Energy Reading",
+	GetCounters:          "Counters Reading",
+	GetLast4Alarms:       "Last Four Alarms Reading",
+}
+
+var commandValues = []Command{
+	GetState,
+	GetPartNumber,
+	GetVersion,
+	GetDSP,
+	GetSerialNumber,
+	GetManufacturingDate,
+	GetFlags,
+	GetTime,
+	SetTime,
+	GetFirmwareVersion,
+	GetLast10SecEnergy,
+	GetConfiguration,
+	GetCumulatedEnergy,
+	GetCounters,
+	GetLast4Alarms,
+}
+
+var commandNames = map[string]Command{
+	"get_state":              GetState,
+	"get_part_number":        GetPartNumber,
+	"get_version":            GetVersion,
+	"get_dsp":                GetDSP,
+	"get_serial_number":      GetSerialNumber,
+	"get_manufacturing_date": GetManufacturingDate,
+	"get_flags":              GetFlags,
+	"get_time":               GetTime,
+	"set_time":               SetTime,
+	"get_firmware_version":   GetFirmwareVersion,
+	"get_last_10_sec_energy": GetLast10SecEnergy,
+	"get_configuration":      GetConfiguration,
+	"get_cumulated_energy":   GetCumulatedEnergy,
+	"get_counters":           GetCounters,
+	"get_last_4_alarms":      GetLast4Alarms,
+}
+
+// CommandByName returns the Command with the given snake_case name
+func CommandByName(name string) (Command, bool) {
+	v, ok := commandNames[name]
+	return v, ok
+}
+
+// Known returns true if the Command is described by the protocol spec
+func (v Command) Known() bool {
+	_, ok := commandStrings[v]
+	return ok
+}
+
+var cumulationPeriodStrings = map[CumulationPeriod]string{
+	CumulatedDaily:     "Daily",
+	CumulatedWeekly:    "Weekly",
+	CumulatedLast7Days: "Last 7 Days",
+	CumulatedMonthly:   "Monthly",
+	CumulatedYearly:    "Yearly",
+	CumulatedTotal:     "Total",
+	CumulatedPartial:   "Partial",
+}
+
+var cumulationPeriodValues = []CumulationPeriod{
+	CumulatedDaily,
+	CumulatedWeekly,
+	CumulatedLast7Days,
+	CumulatedMonthly,
+	CumulatedYearly,
+	CumulatedTotal,
+	CumulatedPartial,
+}
+
+var cumulationPeriodNames = map[string]CumulationPeriod{
+	"daily":       CumulatedDaily,
+	"weekly":      CumulatedWeekly,
+	"last_7_days": CumulatedLast7Days,
+	"monthly":     CumulatedMonthly,
+	"yearly":      CumulatedYearly,
+	"total":       CumulatedTotal,
+	"partial":     CumulatedPartial,
+}
+
+// CumulationPeriodByName returns the CumulationPeriod with the given snake_case name
+func CumulationPeriodByName(name string) (CumulationPeriod, bool) {
+	v, ok := cumulationPeriodNames[name]
+	return v, ok
+}
+
+// Known returns true if the CumulationPeriod is described by the protocol spec
+func (v CumulationPeriod) Known() bool {
+	_, ok := cumulationPeriodStrings[v]
+	return ok
 }
 
 var dsParameterStrings = map[DSParameter]string{
@@ -62,14 +161,188 @@ var dsParameterStrings = map[DSParameter]string{
 	DSPFan4Speed:               "Fan 4 Speed",
 	DSPFan5Speed:               "Fan 5 Speed",
 	DSPPowerSaturationLimit:    "Power Saturation Limit (Der.)",
-	DSPRiferimentoAnelloBulk:   "Refeimento Anello Bulk",
+	DSPRiferimentoAnelloBulk:   "Riferimento Anello Bulk",
 	DSPVpanelMicro:             "Vpanel micro",
 	DSPGridVoltagePhaseR:       "Grid Voltage phase r",
 	DSPGridVoltagePhaseS:       "Grid Voltage phase s",
 	DSPGridVoltagePhaseT:       "Grid Voltage phase t",
 }
 
-var productNames = map[Product]string{
+var dsParameterValues = []DSParameter{
+	DSPGridVoltage,
+	DSPGridCurrent,
+	DSPGridPower,
+	DSPFrequency,
+	DSPVbulk,
+	DSPIleakDCDC,
+	DSPIleakInverter,
+	DSPPin1,
+	DSPPin2,
+	DSPInverterTemperature,
+	DSPBoosterTemperature,
+	DSPInput1Voltage,
+	DSPInput1Current,
+	DSPInput2Voltage,
+	DSPInput2Current,
+	DSPGridVoltageDCDC,
+	DSPGridFrequencyDCDC,
+	DSPIsolationResistance,
+	DSPVbulkDCDC,
+	DSPAverageGridVoltage,
+	DSPVbulkMid,
+	DSPPowerPeak,
+	DSPPowerPeakToday,
+	DSPGridVoltageNeutral,
+	DSPWindGeneratorFrequency,
+	DSPGridVoltageNeutralPhase,
+	DSPGridCurrentPhaseR,
+	DSPGridCurrentPhaseS,
+	DSPGridCurrentPhaseT,
+	DSPFrequencyPhaseR,
+	DSPFrequencyPhaseS,
+	DSPFrequencyPhaseT,
+	DSPVbulkPositive,
+	DSPVbulkNegative,
+	DSPSupervisorTemperature,
+	DSPAlimTemperature,
+	DSPHeatSinkTemperature,
+	DSPTemperature1,
+	DSPTemperature2,
+	DSPTemperature3,
+	DSPFan1Speed,
+	DSPFan2Speed,
+	DSPFan3Speed,
+	DSPFan4Speed,
+	DSPFan5Speed,
+	DSPPowerSaturationLimit,
+	DSPRiferimentoAnelloBulk,
+	DSPVpanelMicro,
+	DSPGridVoltagePhaseR,
+	DSPGridVoltagePhaseS,
+	DSPGridVoltagePhaseT,
+}
+
+var dsParameterNames = map[string]DSParameter{
+	"grid_voltage":               DSPGridVoltage,
+	"grid_current":               DSPGridCurrent,
+	"grid_power":                 DSPGridPower,
+	"frequency":                  DSPFrequency,
+	"vbulk":                      DSPVbulk,
+	"ileak_dcdc":                 DSPIleakDCDC,
+	"ileak_inverter":             DSPIleakInverter,
+	"pin1":                       DSPPin1,
+	"pin2":                       DSPPin2,
+	"inverter_temperature":       DSPInverterTemperature,
+	"booster_temperature":        DSPBoosterTemperature,
+	"input1_voltage":             DSPInput1Voltage,
+	"input1_current":             DSPInput1Current,
+	"input2_voltage":             DSPInput2Voltage,
+	"input2_current":             DSPInput2Current,
+	"grid_voltage_dcdc":          DSPGridVoltageDCDC,
+	"grid_frequency_dcdc":        DSPGridFrequencyDCDC,
+	"isolation_resistance":       DSPIsolationResistance,
+	"vbulk_dcdc":                 DSPVbulkDCDC,
+	"average_grid_voltage":       DSPAverageGridVoltage,
+	"vbulk_mid":                  DSPVbulkMid,
+	"power_peak":                 DSPPowerPeak,
+	"power_peak_today":           DSPPowerPeakToday,
+	"grid_voltage_neutral":       DSPGridVoltageNeutral,
+	"wind_generator_frequency":   DSPWindGeneratorFrequency,
+	"grid_voltage_neutral_phase": DSPGridVoltageNeutralPhase,
+	"grid_current_phase_r":       DSPGridCurrentPhaseR,
+	"grid_current_phase_s":       DSPGridCurrentPhaseS,
+	"grid_current_phase_t":       DSPGridCurrentPhaseT,
+	"frequency_phase_r":          DSPFrequencyPhaseR,
+	"frequency_phase_s":          DSPFrequencyPhaseS,
+	"frequency_phase_t":          DSPFrequencyPhaseT,
+	"vbulk_positive":             DSPVbulkPositive,
+	"vbulk_negative":             DSPVbulkNegative,
+	"supervisor_temperature":     DSPSupervisorTemperature,
+	"alim_temperature":           DSPAlimTemperature,
+	"heat_sink_temperature":      DSPHeatSinkTemperature,
+	"temperature1":               DSPTemperature1,
+	"temperature2":               DSPTemperature2,
+	"temperature3":               DSPTemperature3,
+	"fan1_speed":                 DSPFan1Speed,
+	"fan2_speed":                 DSPFan2Speed,
+	"fan3_speed":                 DSPFan3Speed,
+	"fan4_speed":                 DSPFan4Speed,
+	"fan5_speed":                 DSPFan5Speed,
+	"power_saturation_limit":     DSPPowerSaturationLimit,
+	"riferimento_anello_bulk":    DSPRiferimentoAnelloBulk,
+	"vpanel_micro":               DSPVpanelMicro,
+	"grid_voltage_phase_r":       DSPGridVoltagePhaseR,
+	"grid_voltage_phase_s":       DSPGridVoltagePhaseS,
+	"grid_voltage_phase_t":       DSPGridVoltagePhaseT,
+}
+
+// DSParameterByName returns the DSParameter with the given snake_case name
+func DSParameterByName(name string) (DSParameter, bool) {
+	v, ok := dsParameterNames[name]
+	return v, ok
+}
+
+// Known returns true if the DSParameter is described by the protocol spec
+func (v DSParameter) Known() bool {
+	_, ok := dsParameterStrings[v]
+	return ok
+}
+
+var dsParameterUnits = map[DSParameter]UnitOfMeasure{
+	DSPGridVoltage:             UnitVolts,
+	DSPGridCurrent:             UnitAmps,
+	DSPGridPower:               UnitWatts,
+	DSPFrequency:               UnitHertz,
+	DSPVbulk:                   UnitVolts,
+	DSPIleakDCDC:               UnitAmps,
+	DSPIleakInverter:           UnitAmps,
+	DSPPin1:                    UnitWatts,
+	DSPPin2:                    UnitWatts,
+	DSPInverterTemperature:     UnitCelsius,
+	DSPBoosterTemperature:      UnitCelsius,
+	DSPInput1Voltage:           UnitVolts,
+	DSPInput1Current:           UnitAmps,
+	DSPInput2Voltage:           UnitVolts,
+	DSPInput2Current:           UnitAmps,
+	DSPGridVoltageDCDC:         UnitVolts,
+	DSPGridFrequencyDCDC:       UnitHertz,
+	DSPIsolationResistance:     UnitMegohms,
+	DSPVbulkDCDC:               UnitVolts,
+	DSPAverageGridVoltage:      UnitVolts,
+	DSPVbulkMid:                UnitVolts,
+	DSPPowerPeak:               UnitWatts,
+	DSPPowerPeakToday:          UnitWatts,
+	DSPGridVoltageNeutral:      UnitVolts,
+	DSPWindGeneratorFrequency:  UnitHertz,
+	DSPGridVoltageNeutralPhase: UnitVolts,
+	DSPGridCurrentPhaseR:       UnitAmps,
+	DSPGridCurrentPhaseS:       UnitAmps,
+	DSPGridCurrentPhaseT:       UnitAmps,
+	DSPFrequencyPhaseR:         UnitHertz,
+	DSPFrequencyPhaseS:         UnitHertz,
+	DSPFrequencyPhaseT:         UnitHertz,
+	DSPVbulkPositive:           UnitVolts,
+	DSPVbulkNegative:           UnitVolts,
+	DSPSupervisorTemperature:   UnitCelsius,
+	DSPAlimTemperature:         UnitCelsius,
+	DSPHeatSinkTemperature:     UnitCelsius,
+	DSPTemperature1:            UnitCelsius,
+	DSPTemperature2:            UnitCelsius,
+	DSPTemperature3:            UnitCelsius,
+	DSPFan1Speed:               UnitRPM,
+	DSPFan2Speed:               UnitRPM,
+	DSPFan3Speed:               UnitRPM,
+	DSPFan4Speed:               UnitRPM,
+	DSPFan5Speed:               UnitRPM,
+	DSPPowerSaturationLimit:    UnitWatts,
+	DSPRiferimentoAnelloBulk:   UnitVolts,
+	DSPVpanelMicro:             UnitVolts,
+	DSPGridVoltagePhaseR:       UnitVolts,
+	DSPGridVoltagePhaseS:       UnitVolts,
+	DSPGridVoltagePhaseT:       UnitVolts,
+}
+
+var productStrings = map[Product]string{
 	Product2kWIndoor:       "Aurora 2 kW indoor",
 	Product2kWOutdoor:      "Aurora 2 kW outdoor",
 	Product3_6kWIndoor:     "Aurora 3.6 kW indoor",
@@ -86,7 +359,53 @@ var productNames = map[Product]string{
 	Product10kW:            "Aurora 10 kW",
 }
 
-var productSpecs = map[ProductSpec]string{
+var productValues = []Product{
+	Product2kWIndoor,
+	Product2kWOutdoor,
+	Product3_6kWIndoor,
+	Product3_6kWOutdoor,
+	Product5kWOutdoor,
+	Product6kWOutdoor,
+	Product3PhaseInterface,
+	Product50kWModule,
+	Product4_2kWNew,
+	Product3_6kWNew,
+	Product3_3kWNew,
+	Product3_0kWNew,
+	Product12kW,
+	Product10kW,
+}
+
+var productNames = map[string]Product{
+	"2kw_indoor":        Product2kWIndoor,
+	"2kw_outdoor":       Product2kWOutdoor,
+	"3_6kw_indoor":      Product3_6kWIndoor,
+	"3_6kw_outdoor":     Product3_6kWOutdoor,
+	"5kw_outdoor":       Product5kWOutdoor,
+	"6kw_outdoor":       Product6kWOutdoor,
+	"3_phase_interface": Product3PhaseInterface,
+	"50kw_module":       Product50kWModule,
+	"4_2kw_new":         Product4_2kWNew,
+	"3_6kw_new":         Product3_6kWNew,
+	"3_3kw_new":         Product3_3kWNew,
+	"3_0kw_new":         Product3_0kWNew,
+	"12kw":              Product12kW,
+	"10kw":              Product10kW,
+}
+
+// ProductByName returns the Product with the given snake_case name
+func ProductByName(name string) (Product, bool) {
+	v, ok := productNames[name]
+	return v, ok
+}
+
+// Known returns true if the Product is described by the protocol spec
+func (v Product) Known() bool {
+	_, ok := productStrings[v]
+	return ok
+}
+
+var productSpecStrings = map[ProductSpec]string{
 	ProductSpecUL1741:      "UL1741",
 	ProductSpecVDE0126:     "VDE0126",
 	ProductSpecDR1663_2000: "DR 1663/2000",
@@ -96,7 +415,87 @@ var productSpecs = map[ProductSpec]string{
 	ProductSpecVDEFrench:   "VDE French Model",
 }
 
-var globalStates = map[GlobalState]string{
+var productSpecValues = []ProductSpec{
+	ProductSpecUL1741,
+	ProductSpecVDE0126,
+	ProductSpecDR1663_2000,
+	ProductSpecENELDK5950,
+	ProductSpecUKG83,
+	ProductSpecAS4777,
+	ProductSpecVDEFrench,
+}
+
+var productSpecNames = map[string]ProductSpec{
+	"ul1741":      ProductSpecUL1741,
+	"vde0126":     ProductSpecVDE0126,
+	"dr1663_2000": ProductSpecDR1663_2000,
+	"enel_dk5950": ProductSpecENELDK5950,
+	"uk_g83":      ProductSpecUKG83,
+	"as4777":      ProductSpecAS4777,
+	"vde_french":  ProductSpecVDEFrench,
+}
+
+// ProductSpecByName returns the ProductSpec with the given snake_case name
+func ProductSpecByName(name string) (ProductSpec, bool) {
+	v, ok := productSpecNames[name]
+	return v, ok
+}
+
+// Known returns true if the ProductSpec is described by the protocol spec
+func (v ProductSpec) Known() bool {
+	_, ok := productSpecStrings[v]
+	return ok
+}
+
+var transmissionStateStrings = map[TransmissionState]string{
+	TSOk:                    "Ok",
+	TSCommandNotImplemented: "Command is not implemented",
+	TSVariableDoesNotExist:  "Variable does not exist",
+	TSValueOutOfRange:       "Variable value is out of range",
+	TSEEpromNotAccessible:   "EEProm not accessible",
+	TSNotToggledServiceMode: "Not toggled service mode",
+	TSMicroError:            "Cannot send the command to internal micro",
+	TSNotExecuted:           "Command not executed",
+	TSVariableNotAvailable:  "The variable is not available, retry",
+}
+
+var transmissionStateValues = []TransmissionState{
+	TSOk,
+	TSCommandNotImplemented,
+	TSVariableDoesNotExist,
+	TSValueOutOfRange,
+	TSEEpromNotAccessible,
+	TSNotToggledServiceMode,
+	TSMicroError,
+	TSNotExecuted,
+	TSVariableNotAvailable,
+}
+
+var transmissionStateNames = map[string]TransmissionState{
+	"ok":                       TSOk,
+	"command_not_implemented":  TSCommandNotImplemented,
+	"variable_does_not_exist":  TSVariableDoesNotExist,
+	"value_out_of_range":       TSValueOutOfRange,
+	"eeprom_not_accessible":    TSEEpromNotAccessible,
+	"not_toggled_service_mode": TSNotToggledServiceMode,
+	"micro_error":              TSMicroError,
+	"not_executed":             TSNotExecuted,
+	"variable_not_available":   TSVariableNotAvailable,
+}
+
+// TransmissionStateByName returns the TransmissionState with the given snake_case name
+func TransmissionStateByName(name string) (TransmissionState, bool) {
+	v, ok := transmissionStateNames[name]
+	return v, ok
+}
+
+// Known returns true if the TransmissionState is described by the protocol spec
+func (v TransmissionState) Known() bool {
+	_, ok := transmissionStateStrings[v]
+	return ok
+}
+
+var globalStateStrings = map[GlobalState]string{
 	GSSendingParameters:     "Sending Parameters",
 	GSWaitingSunGrid:        "Wait Sun/Grid",
 	GSCheckingGrid:          "Checking Grid",
@@ -140,7 +539,151 @@ var globalStates = map[GlobalState]string{
 	GSFreeze:                "Freeze",
 }
 
-var inverterStates = map[InverterState]string{
+var globalStateValues = []GlobalState{
+	GSSendingParameters,
+	GSWaitingSunGrid,
+	GSCheckingGrid,
+	GSMeasuringRiso,
+	GSDCDCStart,
+	GSInverterTurnOn,
+	GSRun,
+	GSRecovery,
+	GSPause,
+	GSGroundFault,
+	GSOTHFault,
+	GSAddressSetting,
+	GSSelfTest,
+	GSSelfTestFail,
+	GSSensorTestMeasureRiso,
+	GSLeakFault,
+	GSWaitingManualReset,
+	GSInternalErrorE026,
+	GSInternalErrorE027,
+	GSInternalErrorE028,
+	GSInternalErrorE029,
+	GSInternalErrorE030,
+	GSSendingWindTable,
+	GSFailedSendingTable,
+	GSUTHFault,
+	GSRemoteOff,
+	GSInterlockFail,
+	GSExecutingAutotest,
+	GSWaitingSun,
+	GSTemperatureFault,
+	GSFanStaucked,
+	GSIntComFail,
+	GSSlaveInsertion,
+	GSDCSwitchOpen,
+	GSTrasSwitchOpen,
+	GSMasterExclusion,
+	GSAutoExclusion,
+	GSErasingInternalEEprom,
+	GSErasingExternalEEprom,
+	GSCountingEEprom,
+	GSFreeze,
+}
+
+var globalStateNames = map[string]GlobalState{
+	"sending_parameters":       GSSendingParameters,
+	"waiting_sun_grid":         GSWaitingSunGrid,
+	"checking_grid":            GSCheckingGrid,
+	"measuring_riso":           GSMeasuringRiso,
+	"dcdc_start":               GSDCDCStart,
+	"inverter_turn_on":         GSInverterTurnOn,
+	"run":                      GSRun,
+	"recovery":                 GSRecovery,
+	"pause":                    GSPause,
+	"ground_fault":             GSGroundFault,
+	"oth_fault":                GSOTHFault,
+	"address_setting":          GSAddressSetting,
+	"self_test":                GSSelfTest,
+	"self_test_fail":           GSSelfTestFail,
+	"sensor_test_measure_riso": GSSensorTestMeasureRiso,
+	"leak_fault":               GSLeakFault,
+	"waiting_manual_reset":     GSWaitingManualReset,
+	"internal_error_e026":      GSInternalErrorE026,
+	"internal_error_e027":      GSInternalErrorE027,
+	"internal_error_e028":      GSInternalErrorE028,
+	"internal_error_e029":      GSInternalErrorE029,
+	"internal_error_e030":      GSInternalErrorE030,
+	"sending_wind_table":       GSSendingWindTable,
+	"failed_sending_table":     GSFailedSendingTable,
+	"uth_fault":                GSUTHFault,
+	"remote_off":               GSRemoteOff,
+	"interlock_fail":           GSInterlockFail,
+	"executing_autotest":       GSExecutingAutotest,
+	"waiting_sun":              GSWaitingSun,
+	"temperature_fault":        GSTemperatureFault,
+	"fan_staucked":             GSFanStaucked,
+	"int_com_fail":             GSIntComFail,
+	"slave_insertion":          GSSlaveInsertion,
+	"dc_switch_open":           GSDCSwitchOpen,
+	"tras_switch_open":         GSTrasSwitchOpen,
+	"master_exclusion":         GSMasterExclusion,
+	"auto_exclusion":           GSAutoExclusion,
+	"erasing_internal_eeprom":  GSErasingInternalEEprom,
+	"erasing_external_eeprom":  GSErasingExternalEEprom,
+	"counting_eeprom":          GSCountingEEprom,
+	"freeze":                   GSFreeze,
+}
+
+// GlobalStateByName returns the GlobalState with the given snake_case name
+func GlobalStateByName(name string) (GlobalState, bool) {
+	v, ok := globalStateNames[name]
+	return v, ok
+}
+
+// Known returns true if the GlobalState is described by the protocol spec
+func (v GlobalState) Known() bool {
+	_, ok := globalStateStrings[v]
+	return ok
+}
+
+var globalStateCategories = map[GlobalState]StateCategory{
+	GSSendingParameters:     CategoryStarting,
+	GSWaitingSunGrid:        CategoryWaiting,
+	GSCheckingGrid:          CategoryStarting,
+	GSMeasuringRiso:         CategoryStarting,
+	GSDCDCStart:             CategoryStarting,
+	GSInverterTurnOn:        CategoryStarting,
+	GSRun:                   CategoryProducing,
+	GSRecovery:              CategoryStarting,
+	GSPause:                 CategoryWaiting,
+	GSGroundFault:           CategoryLockout,
+	GSOTHFault:              CategoryFault,
+	GSAddressSetting:        CategoryStarting,
+	GSSelfTest:              CategorySelfTest,
+	GSSelfTestFail:          CategoryFault,
+	GSSensorTestMeasureRiso: CategorySelfTest,
+	GSLeakFault:             CategoryLockout,
+	GSWaitingManualReset:    CategoryLockout,
+	GSInternalErrorE026:     CategoryFault,
+	GSInternalErrorE027:     CategoryFault,
+	GSInternalErrorE028:     CategoryFault,
+	GSInternalErrorE029:     CategoryFault,
+	GSInternalErrorE030:     CategoryFault,
+	GSSendingWindTable:      CategoryStarting,
+	GSFailedSendingTable:    CategoryFault,
+	GSUTHFault:              CategoryFault,
+	GSRemoteOff:             CategoryOff,
+	GSInterlockFail:         CategoryFault,
+	GSExecutingAutotest:     CategorySelfTest,
+	GSWaitingSun:            CategoryWaiting,
+	GSTemperatureFault:      CategoryFault,
+	GSFanStaucked:           CategoryFault,
+	GSIntComFail:            CategoryFault,
+	GSSlaveInsertion:        CategoryStarting,
+	GSDCSwitchOpen:          CategoryOff,
+	GSTrasSwitchOpen:        CategoryOff,
+	GSMasterExclusion:       CategoryOff,
+	GSAutoExclusion:         CategoryOff,
+	GSErasingInternalEEprom: CategoryStarting,
+	GSErasingExternalEEprom: CategoryStarting,
+	GSCountingEEprom:        CategoryStarting,
+	GSFreeze:                CategoryFault,
+}
+
+var inverterStateStrings = map[InverterState]string{
 	ISStandBy:                     "Stand By",
 	ISCheckingGrid:                "Checking Grid",
 	ISRun:                         "Run",
@@ -183,7 +726,148 @@ var inverterStates = map[InverterState]string{
 	ISINPUTOC:                     "Input OC",
 }
 
-var dcdcStates = map[DCDCState]string{
+var inverterStateValues = []InverterState{
+	ISStandBy,
+	ISCheckingGrid,
+	ISRun,
+	ISBulkOverVoltage,
+	ISOutOverCurrent,
+	ISIGBTSat,
+	ISBulkUnderVoltage,
+	ISDegaussError,
+	ISNoParameters,
+	ISBulkLow,
+	ISGridOverVoltage,
+	ISCommunicationError,
+	ISDegaussing,
+	ISStarting,
+	ISBulkCapFail,
+	ISLeakFail,
+	ISDCDCFail,
+	ISIleakSensorFail,
+	ISSelfTestRelayInverter,
+	ISSelfTestWaitSensorTest,
+	ISSelfTestTestRelayDCDCSensor,
+	ISSelfTestRelayInverterFail,
+	ISSelfTestTimeoutFail,
+	ISSelfTestRelayDCDCFail,
+	ISSelfTest1,
+	ISWaitingSelfTestStart,
+	ISDCInjection,
+	ISSelfTest2,
+	ISSelfTest3,
+	ISSelfTest4,
+	ISInternalError30,
+	ISInternalError31,
+	ISForbiddenState,
+	ISInputUC,
+	ISZeroPower,
+	ISGridNotPresent,
+	ISWaitingStart,
+	ISMPPT,
+	ISGRIDFAIL,
+	ISINPUTOC,
+}
+
+var inverterStateNames = map[string]InverterState{
+	"stand_by":                         ISStandBy,
+	"checking_grid":                    ISCheckingGrid,
+	"run":                              ISRun,
+	"bulk_over_voltage":                ISBulkOverVoltage,
+	"out_over_current":                 ISOutOverCurrent,
+	"igbt_sat":                         ISIGBTSat,
+	"bulk_under_voltage":               ISBulkUnderVoltage,
+	"degauss_error":                    ISDegaussError,
+	"no_parameters":                    ISNoParameters,
+	"bulk_low":                         ISBulkLow,
+	"grid_over_voltage":                ISGridOverVoltage,
+	"communication_error":              ISCommunicationError,
+	"degaussing":                       ISDegaussing,
+	"starting":                         ISStarting,
+	"bulk_cap_fail":                    ISBulkCapFail,
+	"leak_fail":                        ISLeakFail,
+	"dcdc_fail":                        ISDCDCFail,
+	"ileak_sensor_fail":                ISIleakSensorFail,
+	"self_test_relay_inverter":         ISSelfTestRelayInverter,
+	"self_test_wait_sensor_test":       ISSelfTestWaitSensorTest,
+	"self_test_test_relay_dcdc_sensor": ISSelfTestTestRelayDCDCSensor,
+	"self_test_relay_inverter_fail":    ISSelfTestRelayInverterFail,
+	"self_test_timeout_fail":           ISSelfTestTimeoutFail,
+	"self_test_relay_dcdc_fail":        ISSelfTestRelayDCDCFail,
+	"self_test1":                       ISSelfTest1,
+	"waiting_self_test_start":          ISWaitingSelfTestStart,
+	"dc_injection":                     ISDCInjection,
+	"self_test2":                       ISSelfTest2,
+	"self_test3":                       ISSelfTest3,
+	"self_test4":                       ISSelfTest4,
+	"internal_error30":                 ISInternalError30,
+	"internal_error31":                 ISInternalError31,
+	"forbidden_state":                  ISForbiddenState,
+	"input_uc":                         ISInputUC,
+	"zero_power":                       ISZeroPower,
+	"grid_not_present":                 ISGridNotPresent,
+	"waiting_start":                    ISWaitingStart,
+	"mppt":                             ISMPPT,
+	"grid_fail":                        ISGRIDFAIL,
+	"input_oc":                         ISINPUTOC,
+}
+
+// InverterStateByName returns the InverterState with the given snake_case name
+func InverterStateByName(name string) (InverterState, bool) {
+	v, ok := inverterStateNames[name]
+	return v, ok
+}
+
+// Known returns true if the InverterState is described by the protocol spec
+func (v InverterState) Known() bool {
+	_, ok := inverterStateStrings[v]
+	return ok
+}
+
+var inverterStateCategories = map[InverterState]StateCategory{
+	ISStandBy:                     CategoryWaiting,
+	ISCheckingGrid:                CategoryStarting,
+	ISRun:                         CategoryProducing,
+	ISBulkOverVoltage:             CategoryFault,
+	ISOutOverCurrent:              CategoryFault,
+	ISIGBTSat:                     CategoryFault,
+	ISBulkUnderVoltage:            CategoryFault,
+	ISDegaussError:                CategoryFault,
+	ISNoParameters:                CategoryFault,
+	ISBulkLow:                     CategoryWaiting,
+	ISGridOverVoltage:             CategoryGrid,
+	ISCommunicationError:          CategoryFault,
+	ISDegaussing:                  CategoryStarting,
+	ISStarting:                    CategoryStarting,
+	ISBulkCapFail:                 CategoryFault,
+	ISLeakFail:                    CategoryLockout,
+	ISDCDCFail:                    CategoryFault,
+	ISIleakSensorFail:             CategoryFault,
+	ISSelfTestRelayInverter:       CategorySelfTest,
+	ISSelfTestWaitSensorTest:      CategorySelfTest,
+	ISSelfTestTestRelayDCDCSensor: CategorySelfTest,
+	ISSelfTestRelayInverterFail:   CategoryFault,
+	ISSelfTestTimeoutFail:         CategoryFault,
+	ISSelfTestRelayDCDCFail:       CategoryFault,
+	ISSelfTest1:                   CategorySelfTest,
+	ISWaitingSelfTestStart:        CategorySelfTest,
+	ISDCInjection:                 CategoryFault,
+	ISSelfTest2:                   CategorySelfTest,
+	ISSelfTest3:                   CategorySelfTest,
+	ISSelfTest4:                   CategorySelfTest,
+	ISInternalError30:             CategoryFault,
+	ISInternalError31:             CategoryFault,
+	ISForbiddenState:              CategoryFault,
+	ISInputUC:                     CategoryWaiting,
+	ISZeroPower:                   CategoryWaiting,
+	ISGridNotPresent:              CategoryGrid,
+	ISWaitingStart:                CategoryStarting,
+	ISMPPT:                        CategoryProducing,
+	ISGRIDFAIL:                    CategoryGrid,
+	ISINPUTOC:                     CategoryFault,
+}
+
+var dcdcStateStrings = map[DCDCState]string{
 	DCDCOff:                "DcDc OFF",
 	DCDCRampStart:          "Ramp Start",
 	DCDCMPPT:               "MPPT",
@@ -205,7 +889,85 @@ var dcdcStates = map[DCDCState]string{
 	DCDCCommError:          "DcDc Comm. Error",
 }
 
-var alarmStates = map[AlarmState]string{
+var dcdcStateValues = []DCDCState{
+	DCDCOff,
+	DCDCRampStart,
+	DCDCMPPT,
+	DCDCInputOverCurrent,
+	DCDCInputUnderVoltage,
+	DCDCInputOverVoltage,
+	DCDCInputLow,
+	DCDCNoParameters,
+	DCDCBulkOverVoltage,
+	DCDCCommunicationError,
+	DCDCRampFail,
+	DCDCInternalError,
+	DCDCInputModeError,
+	DCDCGroundFault,
+	DCDCInverterFail,
+	DCDCIGBTSat,
+	DCDCILEAKFail,
+	DCDCGridFail,
+	DCDCCommError,
+}
+
+var dcdcStateNames = map[string]DCDCState{
+	"off":                 DCDCOff,
+	"ramp_start":          DCDCRampStart,
+	"mppt":                DCDCMPPT,
+	"input_over_current":  DCDCInputOverCurrent,
+	"input_under_voltage": DCDCInputUnderVoltage,
+	"input_over_voltage":  DCDCInputOverVoltage,
+	"input_low":           DCDCInputLow,
+	"no_parameters":       DCDCNoParameters,
+	"bulk_over_voltage":   DCDCBulkOverVoltage,
+	"communication_error": DCDCCommunicationError,
+	"ramp_fail":           DCDCRampFail,
+	"internal_error":      DCDCInternalError,
+	"input_mode_error":    DCDCInputModeError,
+	"ground_fault":        DCDCGroundFault,
+	"inverter_fail":       DCDCInverterFail,
+	"igbt_sat":            DCDCIGBTSat,
+	"ileak_fail":          DCDCILEAKFail,
+	"grid_fail":           DCDCGridFail,
+	"comm_error":          DCDCCommError,
+}
+
+// DCDCStateByName returns the DCDCState with the given snake_case name
+func DCDCStateByName(name string) (DCDCState, bool) {
+	v, ok := dcdcStateNames[name]
+	return v, ok
+}
+
+// Known returns true if the DCDCState is described by the protocol spec
+func (v DCDCState) Known() bool {
+	_, ok := dcdcStateStrings[v]
+	return ok
+}
+
+var dcdcStateCategories = map[DCDCState]StateCategory{
+	DCDCOff:                CategoryWaiting,
+	DCDCRampStart:          CategoryStarting,
+	DCDCMPPT:               CategoryProducing,
+	DCDCInputOverCurrent:   CategoryFault,
+	DCDCInputUnderVoltage:  CategoryWaiting,
+	DCDCInputOverVoltage:   CategoryFault,
+	DCDCInputLow:           CategoryWaiting,
+	DCDCNoParameters:       CategoryFault,
+	DCDCBulkOverVoltage:    CategoryFault,
+	DCDCCommunicationError: CategoryFault,
+	DCDCRampFail:           CategoryFault,
+	DCDCInternalError:      CategoryFault,
+	DCDCInputModeError:     CategoryFault,
+	DCDCGroundFault:        CategoryLockout,
+	DCDCInverterFail:       CategoryFault,
+	DCDCIGBTSat:            CategoryFault,
+	DCDCILEAKFail:          CategoryLockout,
+	DCDCGridFail:           CategoryGrid,
+	DCDCCommError:          CategoryFault,
+}
+
+var alarmStateStrings = map[AlarmState]string{
 	AlarmNone:              "No Alarm",
 	AlarmSunLow1:           "Sun Low W001 (1)",
 	AlarmInputOverCurrent:  "Input Over Current E001",
@@ -221,7 +983,7 @@ var alarmStates = map[AlarmState]string{
 	AlarmE009:              "Internal error E009",
 	AlarmGridFail:          "Grid Fail W003",
 	AlarmBulkLow:           "Bulk Low E010",
-	AlarmRampFail:          "Ramp Fail E010",
+	AlarmRampFail:          "Ramp Fail E011",
 	AlarmDCDCFail16:        "Dc/Dc Fail E012 (16)",
 	AlarmWrongMode:         "Wrong Mode E013",
 	AlarmGroundFault18:     "Ground Fault (18)",
@@ -245,12 +1007,12 @@ var alarmStates = map[AlarmState]string{
 	AlarmZGridHi:           "Z grid Hi W008",
 	AlarmE024:              "Internal Error E024",
 	AlarmRisoLow:           "Risa Low E025",
-	ALarmVrefError:         "Vref Error E026",
+	AlarmVrefError:         "Vref Error E026",
 	AlarmErrorMeasV:        "Error Meas V E027",
 	AlarmErrorMeasF:        "Error Meas F E028",
 	AlarmErrorMeasI:        "Error Meas I E029",
 	AlarmErrorMeasIleak:    "Error Meas Ileak E030",
-	AlarmReadErrorV:        "Read Error V E301",
+	AlarmReadErrorV:        "Read Error V E031",
 	AlarmReadErrorI:        "Read Error I E032",
 	AlarmTableFail:         "Table Fail W009",
 	AlarmFanFail:           "Fan Fail W010",
@@ -271,18 +1033,329 @@ var alarmStates = map[AlarmState]string{
 	AlarmJboxFail:          "Jbox fail",
 }
 
-var configStates = map[ConfigurationState]string{
+var alarmStateValues = []AlarmState{
+	AlarmNone,
+	AlarmSunLow1,
+	AlarmInputOverCurrent,
+	AlarmInputUnderVoltage,
+	AlarmInputOverVoltage,
+	AlarmSunLow5,
+	AlarmNoParameters,
+	AlarmBulkOverVoltage,
+	AlarmCommError,
+	AlarmOutputOverCurrent,
+	AlarmIGBTSat,
+	AlarmBulkUV11,
+	AlarmE009,
+	AlarmGridFail,
+	AlarmBulkLow,
+	AlarmRampFail,
+	AlarmDCDCFail16,
+	AlarmWrongMode,
+	AlarmGroundFault18,
+	AlarmOverTemp,
+	AlarmBulkCapFail,
+	AlarmInverterFail,
+	AlarmStartTimeout,
+	AlarmGroundFault23,
+	AlarmDegaussError,
+	AlarmIleakSensFail,
+	AlarmDCDCFail25,
+	AlarmSelfTestError1,
+	AlarmSelfTestError2,
+	AlarmSelfTestError3,
+	AlarmSelfTestError4,
+	AlarmDCInjError,
+	AlarmGridOverVoltage,
+	AlarmGridUnderVoltage,
+	AlarmGridOF,
+	AlarmGridUF,
+	AlarmZGridHi,
+	AlarmE024,
+	AlarmRisoLow,
+	AlarmVrefError,
+	AlarmErrorMeasV,
+	AlarmErrorMeasF,
+	AlarmErrorMeasI,
+	AlarmErrorMeasIleak,
+	AlarmReadErrorV,
+	AlarmReadErrorI,
+	AlarmTableFail,
+	AlarmFanFail,
+	AlarmUTH,
+	AlarmInterlockFail,
+	AlarmRemoteOff,
+	AlarmVoutAvgError,
+	AlarmBatteryLow,
+	AlarmClkFail,
+	AlarmInputUC,
+	AlarmZeroPower,
+	AlarmFanStucked,
+	AlarmDCSwitchOpen,
+	AlarmBulkUV58,
+	AlarmAutoexclusion,
+	AlarmGridDFDT,
+	AlarmDenSwitchOpen,
+	AlarmJboxFail,
+}
+
+var alarmStateNames = map[string]AlarmState{
+	"none":                AlarmNone,
+	"sun_low1":            AlarmSunLow1,
+	"input_over_current":  AlarmInputOverCurrent,
+	"input_under_voltage": AlarmInputUnderVoltage,
+	"input_over_voltage":  AlarmInputOverVoltage,
+	"sun_low5":            AlarmSunLow5,
+	"no_parameters":       AlarmNoParameters,
+	"bulk_over_voltage":   AlarmBulkOverVoltage,
+	"comm_error":          AlarmCommError,
+	"output_over_current": AlarmOutputOverCurrent,
+	"igbt_sat":            AlarmIGBTSat,
+	"bulk_uv11":           AlarmBulkUV11,
+	"e009":                AlarmE009,
+	"grid_fail":           AlarmGridFail,
+	"bulk_low":            AlarmBulkLow,
+	"ramp_fail":           AlarmRampFail,
+	"dcdc_fail16":         AlarmDCDCFail16,
+	"wrong_mode":          AlarmWrongMode,
+	"ground_fault18":      AlarmGroundFault18,
+	"over_temp":           AlarmOverTemp,
+	"bulk_cap_fail":       AlarmBulkCapFail,
+	"inverter_fail":       AlarmInverterFail,
+	"start_timeout":       AlarmStartTimeout,
+	"ground_fault23":      AlarmGroundFault23,
+	"degauss_error":       AlarmDegaussError,
+	"ileak_sens_fail":     AlarmIleakSensFail,
+	"dcdc_fail25":         AlarmDCDCFail25,
+	"self_test_error1":    AlarmSelfTestError1,
+	"self_test_error2":    AlarmSelfTestError2,
+	"self_test_error3":    AlarmSelfTestError3,
+	"self_test_error4":    AlarmSelfTestError4,
+	"dc_inj_error":        AlarmDCInjError,
+	"grid_over_voltage":   AlarmGridOverVoltage,
+	"grid_under_voltage":  AlarmGridUnderVoltage,
+	"grid_of":             AlarmGridOF,
+	"grid_uf":             AlarmGridUF,
+	"z_grid_hi":           AlarmZGridHi,
+	"e024":                AlarmE024,
+	"riso_low":            AlarmRisoLow,
+	"vref_error":          AlarmVrefError,
+	"error_meas_v":        AlarmErrorMeasV,
+	"error_meas_f":        AlarmErrorMeasF,
+	"error_meas_i":        AlarmErrorMeasI,
+	"error_meas_ileak":    AlarmErrorMeasIleak,
+	"read_error_v":        AlarmReadErrorV,
+	"read_error_i":        AlarmReadErrorI,
+	"table_fail":          AlarmTableFail,
+	"fan_fail":            AlarmFanFail,
+	"uth":                 AlarmUTH,
+	"interlock_fail":      AlarmInterlockFail,
+	"remote_off":          AlarmRemoteOff,
+	"vout_avg_error":      AlarmVoutAvgError,
+	"battery_low":         AlarmBatteryLow,
+	"clk_fail":            AlarmClkFail,
+	"input_uc":            AlarmInputUC,
+	"zero_power":          AlarmZeroPower,
+	"fan_stucked":         AlarmFanStucked,
+	"dc_switch_open":      AlarmDCSwitchOpen,
+	"bulk_uv58":           AlarmBulkUV58,
+	"autoexclusion":       AlarmAutoexclusion,
+	"grid_dfdt":           AlarmGridDFDT,
+	"den_switch_open":     AlarmDenSwitchOpen,
+	"jbox_fail":           AlarmJboxFail,
+}
+
+// AlarmStateByName returns the AlarmState with the given snake_case name
+func AlarmStateByName(name string) (AlarmState, bool) {
+	v, ok := alarmStateNames[name]
+	return v, ok
+}
+
+// Known returns true if the AlarmState is described by the protocol spec
+func (v AlarmState) Known() bool {
+	_, ok := alarmStateStrings[v]
+	return ok
+}
+
+var alarmStateInfo = map[AlarmState]AlarmInfo{
+	AlarmNone:              {Code: "", Severity: SeverityNone, Cause: "Nothing is wrong", Action: "None needed"},
+	AlarmSunLow1:           {Code: "W001", Severity: SeverityInfo, Cause: "Not enough sun on the inputs to produce, normal at dawn, dusk and under heavy cloud", Action: "None needed unless it persists in full sun, then check the array and its wiring"},
+	AlarmInputOverCurrent:  {Code: "E001", Severity: SeverityError, Cause: "Input current above the maximum the inverter accepts", Action: "Check the array is within the input limits of the inverter and its strings are wired as designed"},
+	AlarmInputUnderVoltage: {Code: "W002", Severity: SeverityInfo, Cause: "Input voltage below the minimum to start, normal in low light", Action: "None needed unless it persists in full sun, then check the array and its wiring"},
+	AlarmInputOverVoltage:  {Code: "E002", Severity: SeverityCritical, Cause: "Input voltage above the maximum the inverter accepts, it can be damaged", Action: "Disconnect the array and check the number of modules in each string is within the input limits"},
+	AlarmSunLow5:           {Code: "W001", Severity: SeverityInfo, Cause: "Not enough sun on the inputs to produce, normal at dawn, dusk and under heavy cloud", Action: "None needed unless it persists in full sun, then check the array and its wiring"},
+	AlarmNoParameters:      {Code: "E003", Severity: SeverityError, Cause: "The DSP has no parameters, its configuration is missing or corrupt", Action: "Contact service to reload the inverter parameters"},
+	AlarmBulkOverVoltage:   {Code: "E004", Severity: SeverityError, Cause: "Voltage on the internal bulk capacitors is too high", Action: "Check the input voltage is within limits, if it recurs contact service"},
+	AlarmCommError:         {Code: "E005", Severity: SeverityError, Cause: "Communication between the internal microcontrollers failed", Action: "Turn the inverter off and on again, if it recurs contact service"},
+	AlarmOutputOverCurrent: {Code: "E006", Severity: SeverityError, Cause: "Output current above the maximum the inverter allows", Action: "If it recurs check the grid connection then contact service"},
+	AlarmIGBTSat:           {Code: "E007", Severity: SeverityError, Cause: "An IGBT of the inverter stage saturated", Action: "If it recurs contact service"},
+	AlarmBulkUV11:          {Code: "W011", Severity: SeverityWarning, Cause: "Voltage on the internal bulk capacitors is too low", Action: "None needed unless it persists, then check the input voltage"},
+	AlarmE009:              {Code: "E009", Severity: SeverityError, Cause: "Internal error", Action: "If it recurs contact service"},
+	AlarmGridFail:          {Code: "W003", Severity: SeverityWarning, Cause: "The grid is missing or out of range and the inverter disconnected from it", Action: "None needed if the grid was down, otherwise check the AC breaker and wiring"},
+	AlarmBulkLow:           {Code: "E010", Severity: SeverityError, Cause: "Voltage on the internal bulk capacitors too low to run", Action: "Check the input voltage, if it recurs contact service"},
+	AlarmRampFail:          {Code: "E011", Severity: SeverityError, Cause: "The DC/DC converter took too long to reach its operating point", Action: "Check the input voltage and array wiring, if it recurs contact service"},
+	AlarmDCDCFail16:        {Code: "E012", Severity: SeverityError, Cause: "The DC/DC converter failed", Action: "If it recurs contact service"},
+	AlarmWrongMode:         {Code: "E013", Severity: SeverityError, Cause: "The inputs are wired differently to how the input mode is set, parallel or independent", Action: "Check the input mode switch matches how the strings are wired"},
+	AlarmGroundFault18:     {Code: "E018", Severity: SeverityCritical, Cause: "Leakage current to ground detected on the array", Action: "Have the array and its wiring checked for insulation faults before resetting the inverter"},
+	AlarmOverTemp:          {Code: "E014", Severity: SeverityError, Cause: "The inverter is too hot and has stopped", Action: "Check it is shaded and ventilated and nothing blocks the heat sink"},
+	AlarmBulkCapFail:       {Code: "E015", Severity: SeverityError, Cause: "The internal bulk capacitors failed", Action: "Contact service"},
+	AlarmInverterFail:      {Code: "E016", Severity: SeverityError, Cause: "The inverter stage failed", Action: "Contact service"},
+	AlarmStartTimeout:      {Code: "E017", Severity: SeverityError, Cause: "The inverter took too long to start", Action: "Check the input voltage, if it recurs contact service"},
+	AlarmGroundFault23:     {Code: "E018", Severity: SeverityCritical, Cause: "Leakage current to ground detected on the array", Action: "Have the array and its wiring checked for insulation faults before resetting the inverter"},
+	AlarmDegaussError:      {Code: "", Severity: SeverityError, Cause: "Degaussing the output transformer failed", Action: "If it recurs contact service"},
+	AlarmIleakSensFail:     {Code: "E019", Severity: SeverityError, Cause: "The leakage current sensor failed its test", Action: "If it recurs contact service"},
+	AlarmDCDCFail25:        {Code: "E012", Severity: SeverityError, Cause: "The DC/DC converter failed", Action: "If it recurs contact service"},
+	AlarmSelfTestError1:    {Code: "E020", Severity: SeverityError, Cause: "The inverter relay failed its self test", Action: "If it recurs contact service"},
+	AlarmSelfTestError2:    {Code: "E021", Severity: SeverityError, Cause: "The self test timed out", Action: "If it recurs contact service"},
+	AlarmSelfTestError3:    {Code: "E019", Severity: SeverityError, Cause: "The leakage current sensor failed its self test", Action: "If it recurs contact service"},
+	AlarmSelfTestError4:    {Code: "E022", Severity: SeverityError, Cause: "The DC/DC relay failed its self test", Action: "If it recurs contact service"},
+	AlarmDCInjError:        {Code: "E023", Severity: SeverityError, Cause: "Too much DC injected into the grid", Action: "If it recurs contact service"},
+	AlarmGridOverVoltage:   {Code: "W004", Severity: SeverityWarning, Cause: "Grid voltage above the limit of the regulation", Action: "None needed if it clears, otherwise have the grid voltage and cabling checked"},
+	AlarmGridUnderVoltage:  {Code: "W005", Severity: SeverityWarning, Cause: "Grid voltage below the limit of the regulation", Action: "None needed if it clears, otherwise have the grid voltage and cabling checked"},
+	AlarmGridOF:            {Code: "W006", Severity: SeverityWarning, Cause: "Grid frequency above the limit of the regulation", Action: "None needed if it clears, otherwise contact the grid operator"},
+	AlarmGridUF:            {Code: "W007", Severity: SeverityWarning, Cause: "Grid frequency below the limit of the regulation", Action: "None needed if it clears, otherwise contact the grid operator"},
+	AlarmZGridHi:           {Code: "W008", Severity: SeverityWarning, Cause: "Grid impedance too high", Action: "Have the AC cabling checked for undersized or loose connections"},
+	AlarmE024:              {Code: "E024", Severity: SeverityError, Cause: "Internal error", Action: "If it recurs contact service"},
+	AlarmRisoLow:           {Code: "E025", Severity: SeverityCritical, Cause: "Insulation resistance of the array to ground is too low", Action: "Have the array and its wiring checked for insulation faults, it may clear once dry"},
+	AlarmVrefError:         {Code: "E026", Severity: SeverityError, Cause: "The internal voltage reference is out of range", Action: "Contact service"},
+	AlarmErrorMeasV:        {Code: "E027", Severity: SeverityError, Cause: "Measuring the grid voltage failed", Action: "Contact service"},
+	AlarmErrorMeasF:        {Code: "E028", Severity: SeverityError, Cause: "Measuring the grid frequency failed", Action: "Contact service"},
+	AlarmErrorMeasI:        {Code: "E029", Severity: SeverityError, Cause: "Measuring the output current failed", Action: "Contact service"},
+	AlarmErrorMeasIleak:    {Code: "E030", Severity: SeverityError, Cause: "Measuring the leakage current failed", Action: "Contact service"},
+	AlarmReadErrorV:        {Code: "E031", Severity: SeverityError, Cause: "Reading the output voltage failed", Action: "Contact service"},
+	AlarmReadErrorI:        {Code: "E032", Severity: SeverityError, Cause: "Reading the output current failed", Action: "Contact service"},
+	AlarmTableFail:         {Code: "W009", Severity: SeverityWarning, Cause: "The wind power table is invalid", Action: "Send the wind power table to the inverter again"},
+	AlarmFanFail:           {Code: "W010", Severity: SeverityWarning, Cause: "A fan failed", Action: "Check the fans for obstructions, if it recurs contact service"},
+	AlarmUTH:               {Code: "E033", Severity: SeverityError, Cause: "The inverter is too cold to run", Action: "None needed, it will start once it warms up"},
+	AlarmInterlockFail:     {Code: "", Severity: SeverityError, Cause: "The interlock failed", Action: "Contact service"},
+	AlarmRemoteOff:         {Code: "", Severity: SeverityInfo, Cause: "Turned off remotely", Action: "Turn it back on remotely when ready"},
+	AlarmVoutAvgError:      {Code: "", Severity: SeverityWarning, Cause: "The average output voltage over 10 minutes is above the limit of the regulation", Action: "None needed if it clears, otherwise have the grid voltage and cabling checked"},
+	AlarmBatteryLow:        {Code: "", Severity: SeverityWarning, Cause: "The battery backing the clock is low", Action: "Replace the clock battery"},
+	AlarmClkFail:           {Code: "", Severity: SeverityWarning, Cause: "The clock failed", Action: "Set the time, if it recurs contact service"},
+	AlarmInputUC:           {Code: "", Severity: SeverityInfo, Cause: "Input current too low to produce", Action: "None needed, it is normal in low light"},
+	AlarmZeroPower:         {Code: "", Severity: SeverityInfo, Cause: "The inverter is producing no power", Action: "None needed, it is normal in low light"},
+	AlarmFanStucked:        {Code: "", Severity: SeverityError, Cause: "A fan is stuck", Action: "Check the fans for obstructions, if it recurs contact service"},
+	AlarmDCSwitchOpen:      {Code: "", Severity: SeverityInfo, Cause: "The DC switch is open", Action: "Close the DC switch when ready to produce"},
+	AlarmBulkUV58:          {Code: "W011", Severity: SeverityWarning, Cause: "Voltage on the internal bulk capacitors is too low", Action: "None needed unless it persists, then check the input voltage"},
+	AlarmAutoexclusion:     {Code: "", Severity: SeverityWarning, Cause: "The inverter excluded itself from the system", Action: "Check the alarms that led to it, then reset the inverter"},
+	AlarmGridDFDT:          {Code: "", Severity: SeverityWarning, Cause: "The grid frequency is changing too fast", Action: "None needed if it clears, otherwise contact the grid operator"},
+	AlarmDenSwitchOpen:     {Code: "", Severity: SeverityInfo, Cause: "The Den switch is open", Action: "Close the switch when ready to produce"},
+	AlarmJboxFail:          {Code: "", Severity: SeverityError, Cause: "The junction box failed", Action: "Check the junction box, then contact service"},
+}
+
+var configurationStateStrings = map[ConfigurationState]string{
 	ConfigBoth:    "System operating with both strings.",
 	ConfigString1: "String 1 connected, String 2 disconnected.",
 	ConfigString2: "String 2 connected, String 1 disconnected.",
 }
 
-var inverterTypes = map[InverterType]string{
-	InverterTransformer:     "Transformer",
+var configurationStateValues = []ConfigurationState{
+	ConfigBoth,
+	ConfigString1,
+	ConfigString2,
+}
+
+var configurationStateNames = map[string]ConfigurationState{
+	"both":    ConfigBoth,
+	"string1": ConfigString1,
+	"string2": ConfigString2,
+}
+
+// ConfigurationStateByName returns the ConfigurationState with the given snake_case name
+func ConfigurationStateByName(name string) (ConfigurationState, bool) {
+	v, ok := configurationStateNames[name]
+	return v, ok
+}
+
+// Known returns true if the ConfigurationState is described by the protocol spec
+func (v ConfigurationState) Known() bool {
+	_, ok := configurationStateStrings[v]
+	return ok
+}
+
+var counterStrings = map[Counter]string{
+	CounterTotal:   "Total Running Time",
+	CounterPartial: "Partial Running Time",
+	CounterGrid:    "Grid Connection Time",
+	CounterReset:   "Reset Partial Counters",
+}
+
+var counterValues = []Counter{
+	CounterTotal,
+	CounterPartial,
+	CounterGrid,
+	CounterReset,
+}
+
+var counterNames = map[string]Counter{
+	"total":   CounterTotal,
+	"partial": CounterPartial,
+	"grid":    CounterGrid,
+	"reset":   CounterReset,
+}
+
+// CounterByName returns the Counter with the given snake_case name
+func CounterByName(name string) (Counter, bool) {
+	v, ok := counterNames[name]
+	return v, ok
+}
+
+// Known returns true if the Counter is described by the protocol spec
+func (v Counter) Known() bool {
+	_, ok := counterStrings[v]
+	return ok
+}
+
+var inverterTypeStrings = map[InverterType]string{
 	InverterTransformerless: "Transformerless",
+	InverterTransformer:     "Transformer",
 }
 
-var inputTypes = map[InputType]string{
-	InputWind:         "Wind",
+var inverterTypeValues = []InverterType{
+	InverterTransformerless,
+	InverterTransformer,
+}
+
+var inverterTypeNames = map[string]InverterType{
+	"transformerless": InverterTransformerless,
+	"transformer":     InverterTransformer,
+}
+
+// InverterTypeByName returns the InverterType with the given snake_case name
+func InverterTypeByName(name string) (InverterType, bool) {
+	v, ok := inverterTypeNames[name]
+	return v, ok
+}
+
+// Known returns true if the InverterType is described by the protocol spec
+func (v InverterType) Known() bool {
+	_, ok := inverterTypeStrings[v]
+	return ok
+}
+
+var inputTypeStrings = map[InputType]string{
 	InputPhotovoltaic: "Photovoltaic",
+	InputWind:         "Wind",
+}
+
+var inputTypeValues = []InputType{
+	InputPhotovoltaic,
+	InputWind,
+}
+
+var inputTypeNames = map[string]InputType{
+	"photovoltaic": InputPhotovoltaic,
+	"wind":         InputWind,
+}
+
+// InputTypeByName returns the InputType with the given snake_case name
+func InputTypeByName(name string) (InputType, bool) {
+	v, ok := inputTypeNames[name]
+	return v, ok
+}
+
+// Known returns true if the InputType is described by the protocol spec
+func (v InputType) Known() bool {
+	_, ok := inputTypeStrings[v]
+	return ok
 }
@@ -0,0 +1,1225 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+// Code generated by protogen from protocol.json. DO NOT EDIT.
+
+package aurora
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Name returns the snake_case name of the Command, its number if unknown
+func (v Command) Name() string {
+	switch v {
+	case GetState:
+		return "get_state"
+	case GetPartNumber:
+		return "get_part_number"
+	case GetVersion:
+		return "get_version"
+	case GetDSP:
+		return "get_dsp"
+	case GetSerialNumber:
+		return "get_serial_number"
+	case GetManufacturingDate:
+		return "get_manufacturing_date"
+	case GetFlags:
+		return "get_flags"
+	case GetTime:
+		return "get_time"
+	case SetTime:
+		return "set_time"
+	case GetFirmwareVersion:
+		return "get_firmware_version"
+	case GetLast10SecEnergy:
+		return "get_last_10_sec_energy"
+	case GetConfiguration:
+		return "get_configuration"
+	case GetCumulatedEnergy:
+		return "get_cumulated_energy"
+	case GetCounters:
+		return "get_counters"
+	case GetLast4Alarms:
+		return "get_last_4_alarms"
+	}
+	return strconv.Itoa(int(v))
+}
+
+// MarshalText implements encoding.TextMarshaler, see Name
+func (v Command) MarshalText() ([]byte, error) {
+	return []byte(v.Name()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting a name or a number
+func (v *Command) UnmarshalText(text []byte) error {
+	if value, ok := commandNames[string(text)]; ok {
+		*v = value
+		return nil
+	}
+	n, err := strconv.ParseUint(string(text), 10, 8)
+	if err != nil {
+		return fmt.Errorf("Unknown Command %q", text)
+	}
+	*v = Command(n)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting a name or a number as
+// either a string or a number
+func (v *Command) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		return v.UnmarshalText([]byte(text))
+	}
+	if string(data) == "null" {
+		return nil
+	}
+	var n uint8
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("Unknown Command %s", data)
+	}
+	*v = Command(n)
+	return nil
+}
+
+// Name returns the snake_case name of the CumulationPeriod, its number if unknown
+func (v CumulationPeriod) Name() string {
+	switch v {
+	case CumulatedDaily:
+		return "daily"
+	case CumulatedWeekly:
+		return "weekly"
+	case CumulatedLast7Days:
+		return "last_7_days"
+	case CumulatedMonthly:
+		return "monthly"
+	case CumulatedYearly:
+		return "yearly"
+	case CumulatedTotal:
+		return "total"
+	case CumulatedPartial:
+		return "partial"
+	}
+	return strconv.Itoa(int(v))
+}
+
+// MarshalText implements encoding.TextMarshaler, see Name
+func (v CumulationPeriod) MarshalText() ([]byte, error) {
+	return []byte(v.Name()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting a name or a number
+func (v *CumulationPeriod) UnmarshalText(text []byte) error {
+	if value, ok := cumulationPeriodNames[string(text)]; ok {
+		*v = value
+		return nil
+	}
+	n, err := strconv.ParseUint(string(text), 10, 8)
+	if err != nil {
+		return fmt.Errorf("Unknown CumulationPeriod %q", text)
+	}
+	*v = CumulationPeriod(n)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting a name or a number as
+// either a string or a number
+func (v *CumulationPeriod) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		return v.UnmarshalText([]byte(text))
+	}
+	if string(data) == "null" {
+		return nil
+	}
+	var n uint8
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("Unknown CumulationPeriod %s", data)
+	}
+	*v = CumulationPeriod(n)
+	return nil
+}
+
+// Name returns the snake_case name of the DSParameter, its number if unknown
+func (v DSParameter) Name() string {
+	switch v {
+	case DSPGridVoltage:
+		return "grid_voltage"
+	case DSPGridCurrent:
+		return "grid_current"
+	case DSPGridPower:
+		return "grid_power"
+	case DSPFrequency:
+		return "frequency"
+	case DSPVbulk:
+		return "vbulk"
+	case DSPIleakDCDC:
+		return "ileak_dcdc"
+	case DSPIleakInverter:
+		return "ileak_inverter"
+	case DSPPin1:
+		return "pin1"
+	case DSPPin2:
+		return "pin2"
+	case DSPInverterTemperature:
+		return "inverter_temperature"
+	case DSPBoosterTemperature:
+		return "booster_temperature"
+	case DSPInput1Voltage:
+		return "input1_voltage"
+	case DSPInput1Current:
+		return "input1_current"
+	case DSPInput2Voltage:
+		return "input2_voltage"
+	case DSPInput2Current:
+		return "input2_current"
+	case DSPGridVoltageDCDC:
+		return "grid_voltage_dcdc"
+	case DSPGridFrequencyDCDC:
+		return "grid_frequency_dcdc"
+	case DSPIsolationResistance:
+		return "isolation_resistance"
+	case DSPVbulkDCDC:
+		return "vbulk_dcdc"
+	case DSPAverageGridVoltage:
+		return "average_grid_voltage"
+	case DSPVbulkMid:
+		return "vbulk_mid"
+	case DSPPowerPeak:
+		return "power_peak"
+	case DSPPowerPeakToday:
+		return "power_peak_today"
+	case DSPGridVoltageNeutral:
+		return "grid_voltage_neutral"
+	case DSPWindGeneratorFrequency:
+		return "wind_generator_frequency"
+	case DSPGridVoltageNeutralPhase:
+		return "grid_voltage_neutral_phase"
+	case DSPGridCurrentPhaseR:
+		return "grid_current_phase_r"
+	case DSPGridCurrentPhaseS:
+		return "grid_current_phase_s"
+	case DSPGridCurrentPhaseT:
+		return "grid_current_phase_t"
+	case DSPFrequencyPhaseR:
+		return "frequency_phase_r"
+	case DSPFrequencyPhaseS:
+		return "frequency_phase_s"
+	case DSPFrequencyPhaseT:
+		return "frequency_phase_t"
+	case DSPVbulkPositive:
+		return "vbulk_positive"
+	case DSPVbulkNegative:
+		return "vbulk_negative"
+	case DSPSupervisorTemperature:
+		return "supervisor_temperature"
+	case DSPAlimTemperature:
+		return "alim_temperature"
+	case DSPHeatSinkTemperature:
+		return "heat_sink_temperature"
+	case DSPTemperature1:
+		return "temperature1"
+	case DSPTemperature2:
+		return "temperature2"
+	case DSPTemperature3:
+		return "temperature3"
+	case DSPFan1Speed:
+		return "fan1_speed"
+	case DSPFan2Speed:
+		return "fan2_speed"
+	case DSPFan3Speed:
+		return "fan3_speed"
+	case DSPFan4Speed:
+		return "fan4_speed"
+	case DSPFan5Speed:
+		return "fan5_speed"
+	case DSPPowerSaturationLimit:
+		return "power_saturation_limit"
+	case DSPRiferimentoAnelloBulk:
+		return "riferimento_anello_bulk"
+	case DSPVpanelMicro:
+		return "vpanel_micro"
+	case DSPGridVoltagePhaseR:
+		return "grid_voltage_phase_r"
+	case DSPGridVoltagePhaseS:
+		return "grid_voltage_phase_s"
+	case DSPGridVoltagePhaseT:
+		return "grid_voltage_phase_t"
+	}
+	return strconv.Itoa(int(v))
+}
+
+// MarshalText implements encoding.TextMarshaler, see Name
+func (v DSParameter) MarshalText() ([]byte, error) {
+	return []byte(v.Name()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting a name or a number
+func (v *DSParameter) UnmarshalText(text []byte) error {
+	if value, ok := dsParameterNames[string(text)]; ok {
+		*v = value
+		return nil
+	}
+	n, err := strconv.ParseUint(string(text), 10, 8)
+	if err != nil {
+		return fmt.Errorf("Unknown DSParameter %q", text)
+	}
+	*v = DSParameter(n)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting a name or a number as
+// either a string or a number
+func (v *DSParameter) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		return v.UnmarshalText([]byte(text))
+	}
+	if string(data) == "null" {
+		return nil
+	}
+	var n uint8
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("Unknown DSParameter %s", data)
+	}
+	*v = DSParameter(n)
+	return nil
+}
+
+// Name returns the snake_case name of the Product, its number if unknown
+func (v Product) Name() string {
+	switch v {
+	case Product2kWIndoor:
+		return "2kw_indoor"
+	case Product2kWOutdoor:
+		return "2kw_outdoor"
+	case Product3_6kWIndoor:
+		return "3_6kw_indoor"
+	case Product3_6kWOutdoor:
+		return "3_6kw_outdoor"
+	case Product5kWOutdoor:
+		return "5kw_outdoor"
+	case Product6kWOutdoor:
+		return "6kw_outdoor"
+	case Product3PhaseInterface:
+		return "3_phase_interface"
+	case Product50kWModule:
+		return "50kw_module"
+	case Product4_2kWNew:
+		return "4_2kw_new"
+	case Product3_6kWNew:
+		return "3_6kw_new"
+	case Product3_3kWNew:
+		return "3_3kw_new"
+	case Product3_0kWNew:
+		return "3_0kw_new"
+	case Product12kW:
+		return "12kw"
+	case Product10kW:
+		return "10kw"
+	}
+	return strconv.Itoa(int(v))
+}
+
+// MarshalText implements encoding.TextMarshaler, see Name
+func (v Product) MarshalText() ([]byte, error) {
+	return []byte(v.Name()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting a name or a number
+func (v *Product) UnmarshalText(text []byte) error {
+	if value, ok := productNames[string(text)]; ok {
+		*v = value
+		return nil
+	}
+	n, err := strconv.ParseUint(string(text), 10, 8)
+	if err != nil {
+		return fmt.Errorf("Unknown Product %q", text)
+	}
+	*v = Product(n)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting a name or a number as
+// either a string or a number
+func (v *Product) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		return v.UnmarshalText([]byte(text))
+	}
+	if string(data) == "null" {
+		return nil
+	}
+	var n uint8
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("Unknown Product %s", data)
+	}
+	*v = Product(n)
+	return nil
+}
+
+// Name returns the snake_case name of the ProductSpec, its number if unknown
+func (v ProductSpec) Name() string {
+	switch v {
+	case ProductSpecUL1741:
+		return "ul1741"
+	case ProductSpecVDE0126:
+		return "vde0126"
+	case ProductSpecDR1663_2000:
+		return "dr1663_2000"
+	case ProductSpecENELDK5950:
+		return "enel_dk5950"
+	case ProductSpecUKG83:
+		return "uk_g83"
+	case ProductSpecAS4777:
+		return "as4777"
+	case ProductSpecVDEFrench:
+		return "vde_french"
+	}
+	return strconv.Itoa(int(v))
+}
+
+// MarshalText implements encoding.TextMarshaler, see Name
+func (v ProductSpec) MarshalText() ([]byte, error) {
+	return []byte(v.Name()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting a name or a number
+func (v *ProductSpec) UnmarshalText(text []byte) error {
+	if value, ok := productSpecNames[string(text)]; ok {
+		*v = value
+		return nil
+	}
+	n, err := strconv.ParseUint(string(text), 10, 8)
+	if err != nil {
+		return fmt.Errorf("Unknown ProductSpec %q", text)
+	}
+	*v = ProductSpec(n)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting a name or a number as
+// either a string or a number
+func (v *ProductSpec) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		return v.UnmarshalText([]byte(text))
+	}
+	if string(data) == "null" {
+		return nil
+	}
+	var n uint8
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("Unknown ProductSpec %s", data)
+	}
+	*v = ProductSpec(n)
+	return nil
+}
+
+// Name returns the snake_case name of the TransmissionState, its number if unknown
+func (v TransmissionState) Name() string {
+	switch v {
+	case TSOk:
+		return "ok"
+	case TSCommandNotImplemented:
+		return "command_not_implemented"
+	case TSVariableDoesNotExist:
+		return "variable_does_not_exist"
+	case TSValueOutOfRange:
+		return "value_out_of_range"
+	case TSEEpromNotAccessible:
+		return "eeprom_not_accessible"
+	case TSNotToggledServiceMode:
+		return "not_toggled_service_mode"
+	case TSMicroError:
+		return "micro_error"
+	case TSNotExecuted:
+		return "not_executed"
+	case TSVariableNotAvailable:
+		return "variable_not_available"
+	}
+	return strconv.Itoa(int(v))
+}
+
+// MarshalText implements encoding.TextMarshaler, see Name
+func (v TransmissionState) MarshalText() ([]byte, error) {
+	return []byte(v.Name()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting a name or a number
+func (v *TransmissionState) UnmarshalText(text []byte) error {
+	if value, ok := transmissionStateNames[string(text)]; ok {
+		*v = value
+		return nil
+	}
+	n, err := strconv.ParseUint(string(text), 10, 8)
+	if err != nil {
+		return fmt.Errorf("Unknown TransmissionState %q", text)
+	}
+	*v = TransmissionState(n)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting a name or a number as
+// either a string or a number
+func (v *TransmissionState) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		return v.UnmarshalText([]byte(text))
+	}
+	if string(data) == "null" {
+		return nil
+	}
+	var n uint8
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("Unknown TransmissionState %s", data)
+	}
+	*v = TransmissionState(n)
+	return nil
+}
+
+// Name returns the snake_case name of the GlobalState, its number if unknown
+func (v GlobalState) Name() string {
+	switch v {
+	case GSSendingParameters:
+		return "sending_parameters"
+	case GSWaitingSunGrid:
+		return "waiting_sun_grid"
+	case GSCheckingGrid:
+		return "checking_grid"
+	case GSMeasuringRiso:
+		return "measuring_riso"
+	case GSDCDCStart:
+		return "dcdc_start"
+	case GSInverterTurnOn:
+		return "inverter_turn_on"
+	case GSRun:
+		return "run"
+	case GSRecovery:
+		return "recovery"
+	case GSPause:
+		return "pause"
+	case GSGroundFault:
+		return "ground_fault"
+	case GSOTHFault:
+		return "oth_fault"
+	case GSAddressSetting:
+		return "address_setting"
+	case GSSelfTest:
+		return "self_test"
+	case GSSelfTestFail:
+		return "self_test_fail"
+	case GSSensorTestMeasureRiso:
+		return "sensor_test_measure_riso"
+	case GSLeakFault:
+		return "leak_fault"
+	case GSWaitingManualReset:
+		return "waiting_manual_reset"
+	case GSInternalErrorE026:
+		return "internal_error_e026"
+	case GSInternalErrorE027:
+		return "internal_error_e027"
+	case GSInternalErrorE028:
+		return "internal_error_e028"
+	case GSInternalErrorE029:
+		return "internal_error_e029"
+	case GSInternalErrorE030:
+		return "internal_error_e030"
+	case GSSendingWindTable:
+		return "sending_wind_table"
+	case GSFailedSendingTable:
+		return "failed_sending_table"
+	case GSUTHFault:
+		return "uth_fault"
+	case GSRemoteOff:
+		return "remote_off"
+	case GSInterlockFail:
+		return "interlock_fail"
+	case GSExecutingAutotest:
+		return "executing_autotest"
+	case GSWaitingSun:
+		return "waiting_sun"
+	case GSTemperatureFault:
+		return "temperature_fault"
+	case GSFanStaucked:
+		return "fan_staucked"
+	case GSIntComFail:
+		return "int_com_fail"
+	case GSSlaveInsertion:
+		return "slave_insertion"
+	case GSDCSwitchOpen:
+		return "dc_switch_open"
+	case GSTrasSwitchOpen:
+		return "tras_switch_open"
+	case GSMasterExclusion:
+		return "master_exclusion"
+	case GSAutoExclusion:
+		return "auto_exclusion"
+	case GSErasingInternalEEprom:
+		return "erasing_internal_eeprom"
+	case GSErasingExternalEEprom:
+		return "erasing_external_eeprom"
+	case GSCountingEEprom:
+		return "counting_eeprom"
+	case GSFreeze:
+		return "freeze"
+	}
+	return strconv.Itoa(int(v))
+}
+
+// MarshalText implements encoding.TextMarshaler, see Name
+func (v GlobalState) MarshalText() ([]byte, error) {
+	return []byte(v.Name()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting a name or a number
+func (v *GlobalState) UnmarshalText(text []byte) error {
+	if value, ok := globalStateNames[string(text)]; ok {
+		*v = value
+		return nil
+	}
+	n, err := strconv.ParseUint(string(text), 10, 8)
+	if err != nil {
+		return fmt.Errorf("Unknown GlobalState %q", text)
+	}
+	*v = GlobalState(n)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting a name or a number as
+// either a string or a number
+func (v *GlobalState) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		return v.UnmarshalText([]byte(text))
+	}
+	if string(data) == "null" {
+		return nil
+	}
+	var n uint8
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("Unknown GlobalState %s", data)
+	}
+	*v = GlobalState(n)
+	return nil
+}
+
+// Name returns the snake_case name of the InverterState, its number if unknown
+func (v InverterState) Name() string {
+	switch v {
+	case ISStandBy:
+		return "stand_by"
+	case ISCheckingGrid:
+		return "checking_grid"
+	case ISRun:
+		return "run"
+	case ISBulkOverVoltage:
+		return "bulk_over_voltage"
+	case ISOutOverCurrent:
+		return "out_over_current"
+	case ISIGBTSat:
+		return "igbt_sat"
+	case ISBulkUnderVoltage:
+		return "bulk_under_voltage"
+	case ISDegaussError:
+		return "degauss_error"
+	case ISNoParameters:
+		return "no_parameters"
+	case ISBulkLow:
+		return "bulk_low"
+	case ISGridOverVoltage:
+		return "grid_over_voltage"
+	case ISCommunicationError:
+		return "communication_error"
+	case ISDegaussing:
+		return "degaussing"
+	case ISStarting:
+		return "starting"
+	case ISBulkCapFail:
+		return "bulk_cap_fail"
+	case ISLeakFail:
+		return "leak_fail"
+	case ISDCDCFail:
+		return "dcdc_fail"
+	case ISIleakSensorFail:
+		return "ileak_sensor_fail"
+	case ISSelfTestRelayInverter:
+		return "self_test_relay_inverter"
+	case ISSelfTestWaitSensorTest:
+		return "self_test_wait_sensor_test"
+	case ISSelfTestTestRelayDCDCSensor:
+		return "self_test_test_relay_dcdc_sensor"
+	case ISSelfTestRelayInverterFail:
+		return "self_test_relay_inverter_fail"
+	case ISSelfTestTimeoutFail:
+		return "self_test_timeout_fail"
+	case ISSelfTestRelayDCDCFail:
+		return "self_test_relay_dcdc_fail"
+	case ISSelfTest1:
+		return "self_test1"
+	case ISWaitingSelfTestStart:
+		return "waiting_self_test_start"
+	case ISDCInjection:
+		return "dc_injection"
+	case ISSelfTest2:
+		return "self_test2"
+	case ISSelfTest3:
+		return "self_test3"
+	case ISSelfTest4:
+		return "self_test4"
+	case ISInternalError30:
+		return "internal_error30"
+	case ISInternalError31:
+		return "internal_error31"
+	case ISForbiddenState:
+		return "forbidden_state"
+	case ISInputUC:
+		return "input_uc"
+	case ISZeroPower:
+		return "zero_power"
+	case ISGridNotPresent:
+		return "grid_not_present"
+	case ISWaitingStart:
+		return "waiting_start"
+	case ISMPPT:
+		return "mppt"
+	case ISGRIDFAIL:
+		return "grid_fail"
+	case ISINPUTOC:
+		return "input_oc"
+	}
+	return strconv.Itoa(int(v))
+}
+
+// MarshalText implements encoding.TextMarshaler, see Name
+func (v InverterState) MarshalText() ([]byte, error) {
+	return []byte(v.Name()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting a name or a number
+func (v *InverterState) UnmarshalText(text []byte) error {
+	if value, ok := inverterStateNames[string(text)]; ok {
+		*v = value
+		return nil
+	}
+	n, err := strconv.ParseUint(string(text), 10, 8)
+	if err != nil {
+		return fmt.Errorf("Unknown InverterState %q", text)
+	}
+	*v = InverterState(n)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting a name or a number as
+// either a string or a number
+func (v *InverterState) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		return v.UnmarshalText([]byte(text))
+	}
+	if string(data) == "null" {
+		return nil
+	}
+	var n uint8
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("Unknown InverterState %s", data)
+	}
+	*v = InverterState(n)
+	return nil
+}
+
+// Name returns the snake_case name of the DCDCState, its number if unknown
+func (v DCDCState) Name() string {
+	switch v {
+	case DCDCOff:
+		return "off"
+	case DCDCRampStart:
+		return "ramp_start"
+	case DCDCMPPT:
+		return "mppt"
+	case DCDCInputOverCurrent:
+		return "input_over_current"
+	case DCDCInputUnderVoltage:
+		return "input_under_voltage"
+	case DCDCInputOverVoltage:
+		return "input_over_voltage"
+	case DCDCInputLow:
+		return "input_low"
+	case DCDCNoParameters:
+		return "no_parameters"
+	case DCDCBulkOverVoltage:
+		return "bulk_over_voltage"
+	case DCDCCommunicationError:
+		return "communication_error"
+	case DCDCRampFail:
+		return "ramp_fail"
+	case DCDCInternalError:
+		return "internal_error"
+	case DCDCInputModeError:
+		return "input_mode_error"
+	case DCDCGroundFault:
+		return "ground_fault"
+	case DCDCInverterFail:
+		return "inverter_fail"
+	case DCDCIGBTSat:
+		return "igbt_sat"
+	case DCDCILEAKFail:
+		return "ileak_fail"
+	case DCDCGridFail:
+		return "grid_fail"
+	case DCDCCommError:
+		return "comm_error"
+	}
+	return strconv.Itoa(int(v))
+}
+
+// MarshalText implements encoding.TextMarshaler, see Name
+func (v DCDCState) MarshalText() ([]byte, error) {
+	return []byte(v.Name()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting a name or a number
+func (v *DCDCState) UnmarshalText(text []byte) error {
+	if value, ok := dcdcStateNames[string(text)]; ok {
+		*v = value
+		return nil
+	}
+	n, err := strconv.ParseUint(string(text), 10, 8)
+	if err != nil {
+		return fmt.Errorf("Unknown DCDCState %q", text)
+	}
+	*v = DCDCState(n)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting a name or a number as
+// either a string or a number
+func (v *DCDCState) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		return v.UnmarshalText([]byte(text))
+	}
+	if string(data) == "null" {
+		return nil
+	}
+	var n uint8
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("Unknown DCDCState %s", data)
+	}
+	*v = DCDCState(n)
+	return nil
+}
+
+// Name returns the snake_case name of the AlarmState, its number if unknown
+func (v AlarmState) Name() string {
+	switch v {
+	case AlarmNone:
+		return "none"
+	case AlarmSunLow1:
+		return "sun_low1"
+	case AlarmInputOverCurrent:
+		return "input_over_current"
+	case AlarmInputUnderVoltage:
+		return "input_under_voltage"
+	case AlarmInputOverVoltage:
+		return "input_over_voltage"
+	case AlarmSunLow5:
+		return "sun_low5"
+	case AlarmNoParameters:
+		return "no_parameters"
+	case AlarmBulkOverVoltage:
+		return "bulk_over_voltage"
+	case AlarmCommError:
+		return "comm_error"
+	case AlarmOutputOverCurrent:
+		return "output_over_current"
+	case AlarmIGBTSat:
+		return "igbt_sat"
+	case AlarmBulkUV11:
+		return "bulk_uv11"
+	case AlarmE009:
+		return "e009"
+	case AlarmGridFail:
+		return "grid_fail"
+	case AlarmBulkLow:
+		return "bulk_low"
+	case AlarmRampFail:
+		return "ramp_fail"
+	case AlarmDCDCFail16:
+		return "dcdc_fail16"
+	case AlarmWrongMode:
+		return "wrong_mode"
+	case AlarmGroundFault18:
+		return "ground_fault18"
+	case AlarmOverTemp:
+		return "over_temp"
+	case AlarmBulkCapFail:
+		return "bulk_cap_fail"
+	case AlarmInverterFail:
+		return "inverter_fail"
+	case AlarmStartTimeout:
+		return "start_timeout"
+	case AlarmGroundFault23:
+		return "ground_fault23"
+	case AlarmDegaussError:
+		return "degauss_error"
+	case AlarmIleakSensFail:
+		return "ileak_sens_fail"
+	case AlarmDCDCFail25:
+		return "dcdc_fail25"
+	case AlarmSelfTestError1:
+		return "self_test_error1"
+	case AlarmSelfTestError2:
+		return "self_test_error2"
+	case AlarmSelfTestError3:
+		return "self_test_error3"
+	case AlarmSelfTestError4:
+		return "self_test_error4"
+	case AlarmDCInjError:
+		return "dc_inj_error"
+	case AlarmGridOverVoltage:
+		return "grid_over_voltage"
+	case AlarmGridUnderVoltage:
+		return "grid_under_voltage"
+	case AlarmGridOF:
+		return "grid_of"
+	case AlarmGridUF:
+		return "grid_uf"
+	case AlarmZGridHi:
+		return "z_grid_hi"
+	case AlarmE024:
+		return "e024"
+	case AlarmRisoLow:
+		return "riso_low"
+	case AlarmVrefError:
+		return "vref_error"
+	case AlarmErrorMeasV:
+		return "error_meas_v"
+	case AlarmErrorMeasF:
+		return "error_meas_f"
+	case AlarmErrorMeasI:
+		return "error_meas_i"
+	case AlarmErrorMeasIleak:
+		return "error_meas_ileak"
+	case AlarmReadErrorV:
+		return "read_error_v"
+	case AlarmReadErrorI:
+		return "read_error_i"
+	case AlarmTableFail:
+		return "table_fail"
+	case AlarmFanFail:
+		return "fan_fail"
+	case AlarmUTH:
+		return "uth"
+	case AlarmInterlockFail:
+		return "interlock_fail"
+	case AlarmRemoteOff:
+		return "remote_off"
+	case AlarmVoutAvgError:
+		return "vout_avg_error"
+	case AlarmBatteryLow:
+		return "battery_low"
+	case AlarmClkFail:
+		return "clk_fail"
+	case AlarmInputUC:
+		return "input_uc"
+	case AlarmZeroPower:
+		return "zero_power"
+	case AlarmFanStucked:
+		return "fan_stucked"
+	case AlarmDCSwitchOpen:
+		return "dc_switch_open"
+	case AlarmBulkUV58:
+		return "bulk_uv58"
+	case AlarmAutoexclusion:
+		return "autoexclusion"
+	case AlarmGridDFDT:
+		return "grid_dfdt"
+	case AlarmDenSwitchOpen:
+		return "den_switch_open"
+	case AlarmJboxFail:
+		return "jbox_fail"
+	}
+	return strconv.Itoa(int(v))
+}
+
+// MarshalText implements encoding.TextMarshaler, see Name
+func (v AlarmState) MarshalText() ([]byte, error) {
+	return []byte(v.Name()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting a name or a number
+func (v *AlarmState) UnmarshalText(text []byte) error {
+	if value, ok := alarmStateNames[string(text)]; ok {
+		*v = value
+		return nil
+	}
+	n, err := strconv.ParseUint(string(text), 10, 8)
+	if err != nil {
+		return fmt.Errorf("Unknown AlarmState %q", text)
+	}
+	*v = AlarmState(n)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting a name or a number as
+// either a string or a number
+func (v *AlarmState) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		return v.UnmarshalText([]byte(text))
+	}
+	if string(data) == "null" {
+		return nil
+	}
+	var n uint8
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("Unknown AlarmState %s", data)
+	}
+	*v = AlarmState(n)
+	return nil
+}
+
+// Name returns the snake_case name of the ConfigurationState, its number if unknown
+func (v ConfigurationState) Name() string {
+	switch v {
+	case ConfigBoth:
+		return "both"
+	case ConfigString1:
+		return "string1"
+	case ConfigString2:
+		return "string2"
+	}
+	return strconv.Itoa(int(v))
+}
+
+// MarshalText implements encoding.TextMarshaler, see Name
+func (v ConfigurationState) MarshalText() ([]byte, error) {
+	return []byte(v.Name()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting a name or a number
+func (v *ConfigurationState) UnmarshalText(text []byte) error {
+	if value, ok := configurationStateNames[string(text)]; ok {
+		*v = value
+		return nil
+	}
+	n, err := strconv.ParseUint(string(text), 10, 8)
+	if err != nil {
+		return fmt.Errorf("Unknown ConfigurationState %q", text)
+	}
+	*v = ConfigurationState(n)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting a name or a number as
+// either a string or a number
+func (v *ConfigurationState) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		return v.UnmarshalText([]byte(text))
+	}
+	if string(data) == "null" {
+		return nil
+	}
+	var n uint8
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("Unknown ConfigurationState %s", data)
+	}
+	*v = ConfigurationState(n)
+	return nil
+}
+
+// Name returns the snake_case name of the Counter, its number if unknown
+func (v Counter) Name() string {
+	switch v {
+	case CounterTotal:
+		return "total"
+	case CounterPartial:
+		return "partial"
+	case CounterGrid:
+		return "grid"
+	case CounterReset:
+		return "reset"
+	}
+	return strconv.Itoa(int(v))
+}
+
+// MarshalText implements encoding.TextMarshaler, see Name
+func (v Counter) MarshalText() ([]byte, error) {
+	return []byte(v.Name()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting a name or a number
+func (v *Counter) UnmarshalText(text []byte) error {
+	if value, ok := counterNames[string(text)]; ok {
+		*v = value
+		return nil
+	}
+	n, err := strconv.ParseUint(string(text), 10, 8)
+	if err != nil {
+		return fmt.Errorf("Unknown Counter %q", text)
+	}
+	*v = Counter(n)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting a name or a number as
+// either a string or a number
+func (v *Counter) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		return v.UnmarshalText([]byte(text))
+	}
+	if string(data) == "null" {
+		return nil
+	}
+	var n uint8
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("Unknown Counter %s", data)
+	}
+	*v = Counter(n)
+	return nil
+}
+
+// Name returns the snake_case name of the InverterType, its number if unknown
+func (v InverterType) Name() string {
+	switch v {
+	case InverterTransformerless:
+		return "transformerless"
+	case InverterTransformer:
+		return "transformer"
+	}
+	return strconv.Itoa(int(v))
+}
+
+// MarshalText implements encoding.TextMarshaler, see Name
+func (v InverterType) MarshalText() ([]byte, error) {
+	return []byte(v.Name()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting a name or a number
+func (v *InverterType) UnmarshalText(text []byte) error {
+	if value, ok := inverterTypeNames[string(text)]; ok {
+		*v = value
+		return nil
+	}
+	n, err := strconv.ParseUint(string(text), 10, 8)
+	if err != nil {
+		return fmt.Errorf("Unknown InverterType %q", text)
+	}
+	*v = InverterType(n)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting a name or a number as
+// either a string or a number
+func (v *InverterType) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		return v.UnmarshalText([]byte(text))
+	}
+	if string(data) == "null" {
+		return nil
+	}
+	var n uint8
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("Unknown InverterType %s", data)
+	}
+	*v = InverterType(n)
+	return nil
+}
+
+// Name returns the snake_case name of the InputType, its number if unknown
+func (v InputType) Name() string {
+	switch v {
+	case InputPhotovoltaic:
+		return "photovoltaic"
+	case InputWind:
+		return "wind"
+	}
+	return strconv.Itoa(int(v))
+}
+
+// MarshalText implements encoding.TextMarshaler, see Name
+func (v InputType) MarshalText() ([]byte, error) {
+	return []byte(v.Name()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting a name or a number
+func (v *InputType) UnmarshalText(text []byte) error {
+	if value, ok := inputTypeNames[string(text)]; ok {
+		*v = value
+		return nil
+	}
+	n, err := strconv.ParseUint(string(text), 10, 8)
+	if err != nil {
+		return fmt.Errorf("Unknown InputType %q", text)
+	}
+	*v = InputType(n)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting a name or a number as
+// either a string or a number
+func (v *InputType) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		return v.UnmarshalText([]byte(text))
+	}
+	if string(data) == "null" {
+		return nil
+	}
+	var n uint8
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("Unknown InputType %s", data)
+	}
+	*v = InputType(n)
+	return nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package aurora_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/freman/go-aurora"
+)
+
+func TestEnumName(t *testing.T) {
+	tests := []struct {
+		name     interface{ Name() string }
+		expected string
+	}{
+		{aurora.GSRun, "run"},
+		{aurora.ConfigBoth, "both"},
+		{aurora.AlarmVrefError, "vref_error"},
+		{aurora.Product3_6kWOutdoor, "3_6kw_outdoor"},
+		{aurora.InputWind, "wind"},
+		{aurora.DSPGridPower, "grid_power"},
+		{aurora.GlobalState(250), "250"},
+	}
+
+	for _, test := range tests {
+		if got := test.name.Name(); got != test.expected {
+			t.Errorf("Expected %q got %q", test.expected, got)
+		}
+	}
+}
+
+func TestEnumKnown(t *testing.T) {
+	tests := []struct {
+		value    interface{ Known() bool }
+		expected bool
+	}{
+		{aurora.DSPGridPower, true},
+		{aurora.DSParameter(0), false},
+		{aurora.DSParameter(255), false},
+		{aurora.GetLast4Alarms, true},
+		{aurora.Command(99), false},
+		{aurora.AlarmNone, true},
+	}
+
+	for _, test := range tests {
+		if got := test.value.Known(); got != test.expected {
+			t.Errorf("Expected %t for %v got %t", test.expected, test.value, got)
+		}
+	}
+}
+
+func TestEnumJSON(t *testing.T) {
+	state := aurora.State{
+		Global:   aurora.GSRun,
+		Inverter: aurora.InverterState(2),
+		Channel1: aurora.DCDCState(2),
+		Channel2: aurora.DCDCState(250),
+		Alarm:    aurora.AlarmNone,
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"Global":"run","Inverter":"run","Channel1":"mppt","Channel2":"250","Alarm":"none"}`
+	if string(data) != expected {
+		t.Errorf("Expected %s got %s", expected, data)
+	}
+
+	var decoded aurora.State
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded != state {
+		t.Errorf("Expected %+v got %+v", state, decoded)
+	}
+
+	// Numbers, as written before the enums had names, still decode
+	var version aurora.Version
+	if err := json.Unmarshal([]byte(`{"Model":79,"Regulation":"vde0126","Transformer":"78","Type":87}`), &version); err != nil {
+		t.Fatal(err)
+	}
+	if version.Model != aurora.Product3_6kWOutdoor || version.Regulation != aurora.ProductSpecVDE0126 || version.Transformer != aurora.InverterTransformerless || version.Type != aurora.InputWind {
+		t.Errorf("Unexpected %+v", version)
+	}
+
+	alarms := []aurora.AlarmState{aurora.AlarmNone, aurora.AlarmGridFail}
+	data, err = json.Marshal(alarms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `["none","grid_fail"]` {
+		t.Errorf("Unexpected %s", data)
+	}
+	var decodedAlarms []aurora.AlarmState
+	if err := json.Unmarshal(data, &decodedAlarms); err != nil || !reflect.DeepEqual(alarms, decodedAlarms) {
+		t.Errorf("Expected %v got %v (%v)", alarms, decodedAlarms, err)
+	}
+
+	for _, invalid := range []string{`"not_a_state"`, `"256"`, `-1`, `true`} {
+		var g aurora.GlobalState
+		if err := json.Unmarshal([]byte(invalid), &g); err == nil {
+			t.Errorf("Expected error unmarshalling %s", invalid)
+		}
+	}
+}
+
+func TestEnumTextKeys(t *testing.T) {
+	data, err := json.Marshal(map[aurora.CumulationPeriod]int{aurora.CumulatedDaily: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"daily":1}` {
+		t.Errorf("Unexpected %s", data)
+	}
+
+	var periods map[aurora.CumulationPeriod]int
+	if err := json.Unmarshal([]byte(`{"daily":1,"5":2}`), &periods); err != nil {
+		t.Fatal(err)
+	}
+	if periods[aurora.CumulatedDaily] != 1 || periods[aurora.CumulatedTotal] != 2 {
+		t.Errorf("Unexpected %v", periods)
+	}
+}
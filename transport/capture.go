@@ -0,0 +1,169 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package transport
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// Direction is the direction data travelled in
+type Direction string
+
+// Directions
+const (
+	TX Direction = "tx" // Written to the bus
+	RX Direction = "rx" // Read from the bus
+)
+
+// Errors recorded in a capture
+const (
+	CaptureTimeout = "timeout"
+	CaptureEOF     = "eof"
+)
+
+// Event is a single entry in a capture.
+//
+// A capture is a record of everything that crossed the wire, written by Record as
+// JSON lines with one Event per line:
+//
+//	{"time":"2016-06-01T12:00:00.0012Z","dir":"tx","address":2,"data":"023a002020202020c959"}
+//	{"time":"2016-06-01T12:00:00.0153Z","dir":"rx","address":2,"data":"00064f4b4e4e8530"}
+//	{"time":"2016-06-01T12:00:01.0012Z","dir":"rx","address":2,"error":"timeout"}
+//
+// Transmitted events hold the data of a single write and received events that of
+// a single read, so a response may be split over several. Received events carry
+// the address of the last request transmitted, as responses don't include one.
+// A read that failed is recorded with an error of "timeout", "eof" or the text of
+// the error.
+type Event struct {
+	Time    time.Time `json:"time"`
+	Dir     Direction `json:"dir"`
+	Address byte      `json:"address"`
+	Data    Hex       `json:"data,omitempty"`
+	Error   string    `json:"error,omitempty"`
+}
+
+// Hex is a byte slice that is hex encoded as text
+type Hex []byte
+
+// MarshalText implements encoding.TextMarshaler
+func (h Hex) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(h)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (h *Hex) UnmarshalText(text []byte) (err error) {
+	*h, err = hex.DecodeString(string(text))
+	return
+}
+
+// Recorder wraps a connection and records everything that crosses it
+type Recorder struct {
+	conn io.ReadWriter
+
+	mu      sync.Mutex
+	enc     *json.Encoder
+	address byte
+}
+
+// recorders pass through the deadlines and flushing used by the aurora package
+// to drain and time out, but only if the connection supports them
+type deadlineRecorder struct {
+	*Recorder
+	deadliner
+}
+
+type flushRecorder struct {
+	*Recorder
+	flusher
+}
+
+type deadliner interface {
+	SetReadDeadline(t time.Time) error
+	SetWriteDeadline(t time.Time) error
+}
+
+type flusher interface {
+	Flush() error
+}
+
+// Record wraps conn, writing a capture of everything read from or written to it
+// to w. The connection returned supports deadlines or flushing if conn does.
+func Record(conn io.ReadWriter, w io.Writer) io.ReadWriteCloser {
+	r := &Recorder{conn: conn, enc: json.NewEncoder(w)}
+	if d, ok := conn.(deadliner); ok {
+		return &deadlineRecorder{Recorder: r, deadliner: d}
+	}
+	if f, ok := conn.(flusher); ok {
+		return &flushRecorder{Recorder: r, flusher: f}
+	}
+	return r
+}
+
+// Read implements io.Reader
+func (r *Recorder) Read(p []byte) (int, error) {
+	n, err := r.conn.Read(p)
+	r.record(RX, p[:n], err)
+	return n, err
+}
+
+// Write implements io.Writer
+func (r *Recorder) Write(p []byte) (int, error) {
+	n, err := r.conn.Write(p)
+	r.record(TX, p[:n], err)
+	return n, err
+}
+
+// Close closes the connection if it can be closed, it doesn't close the writer
+// the capture is written to
+func (r *Recorder) Close() error {
+	if c, ok := r.conn.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+func (r *Recorder) record(dir Direction, data []byte, err error) {
+	if len(data) == 0 && err == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if dir == TX && len(data) > 0 {
+		r.address = data[0]
+	}
+
+	event := Event{
+		Time:    time.Now().UTC(),
+		Dir:     dir,
+		Address: r.address,
+		Data:    Hex(append([]byte(nil), data...)),
+	}
+	if err != nil {
+		event.Error = captureError(err)
+	}
+
+	// The capture is best effort, it mustn't get in the way of the exchange
+	r.enc.Encode(event)
+}
+
+func captureError(err error) string {
+	var netErr net.Error
+	switch {
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return CaptureTimeout
+	case err == io.EOF:
+		return CaptureEOF
+	}
+	return err.Error()
+}
@@ -0,0 +1,100 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package transport_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/freman/go-aurora"
+	"github.com/freman/go-aurora/aurorasim"
+	"github.com/freman/go-aurora/transport"
+)
+
+func TestRecordReplay(t *testing.T) {
+	ttys0, ttys1 := net.Pipe()
+	sim := aurorasim.New(aurorasim.NewInverter(2))
+	go sim.Serve(ttys1)
+
+	capture := new(bytes.Buffer)
+	conn := transport.Record(ttys0, capture)
+	recorded := aurora.NewBus(conn).Inverter(2)
+
+	power, err := recorded.GridPower()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := recorded.GetDSPData(aurora.DSPFan1Speed); err == nil {
+		t.Fatal("Expected error reading fan speed")
+	}
+	conn.Close()
+
+	// Every event from the first request on names the inverter
+	transmitted := false
+	for _, line := range strings.Split(strings.TrimSpace(capture.String()), "\n") {
+		var event transport.Event
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatal(err)
+		}
+		transmitted = transmitted || event.Dir == transport.TX
+		if transmitted && event.Address != 2 {
+			t.Errorf("Expected address %d got %d in %s", 2, event.Address, line)
+		}
+	}
+
+	replayer, err := transport.Replay(capture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayed := aurora.NewBus(replayer).Inverter(2)
+
+	if replayedPower, err := replayed.GridPower(); err != nil || replayedPower != power {
+		t.Errorf("Expected %f got %f (%v)", power, replayedPower, err)
+	}
+	if _, err := replayed.GetDSPData(aurora.DSPFan1Speed); !errors.Is(err, aurora.TSVariableDoesNotExist) {
+		t.Errorf("Expected %v got %v", aurora.TSVariableDoesNotExist, err)
+	}
+	if remaining := replayer.Remaining(); remaining != 0 {
+		t.Errorf("Expected capture to be exhausted, %d events remain", remaining)
+	}
+}
+
+func TestReplayMismatch(t *testing.T) {
+	// A request for the version of inverter 2 that went unanswered
+	capture := `{"time":"2016-06-01T12:00:00Z","dir":"tx","address":2,"data":"023a002020202020c959"}
+{"time":"2016-06-01T12:00:01Z","dir":"rx","address":2,"error":"timeout"}
+`
+	replayer, err := transport.Replay(strings.NewReader(capture))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = (&aurora.Inverter{Conn: replayer, Address: 3}).Version()
+	if !errors.Is(err, transport.ErrReplayMismatch) {
+		t.Errorf("Expected %v got %v", transport.ErrReplayMismatch, err)
+	}
+
+	replayer, _ = transport.Replay(strings.NewReader(capture))
+	_, err = (&aurora.Inverter{Conn: replayer, Address: 2}).Version()
+	if !errors.Is(err, aurora.ErrTimeout) {
+		t.Errorf("Expected %v got %v", aurora.ErrTimeout, err)
+	}
+}
+
+func TestReplayInvalid(t *testing.T) {
+	for _, capture := range []string{
+		`{"dir":"tx","data":"zz"}`,
+		`{"dir":"sideways"}`,
+		`not json`,
+	} {
+		if _, err := transport.Replay(strings.NewReader(capture)); err == nil {
+			t.Errorf("Expected error replaying %s", capture)
+		}
+	}
+}
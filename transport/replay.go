@@ -0,0 +1,160 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package transport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrReplayMismatch is returned by Replayer.Write when the data written differs
+// from what was transmitted in the capture
+var ErrReplayMismatch = errors.New("Write doesn't match capture")
+
+// Replayer plays a capture back, expecting the same requests to be written as
+// were recorded and answering each with what was received in response
+type Replayer struct {
+	mu      sync.Mutex
+	events  []Event
+	pending []byte // Remainder of a received event partially read
+}
+
+// Replay reads a capture written by Record
+func Replay(r io.Reader) (*Replayer, error) {
+	var events []Event
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("capture line %d: %v", line, err)
+		}
+		if event.Dir != TX && event.Dir != RX {
+			return nil, fmt.Errorf("capture line %d: unknown direction %q", line, event.Dir)
+		}
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return &Replayer{events: events}, nil
+}
+
+// ReplayFile reads a capture from the named file
+func ReplayFile(name string) (*Replayer, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Replay(f)
+}
+
+// Read implements io.Reader, returning what was received next in the capture.
+// Should the capture have nothing to read before the next transmission the read
+// times out, once the capture is exhausted it returns io.EOF.
+func (r *Replayer) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for len(r.pending) == 0 {
+		if len(r.events) == 0 {
+			return 0, io.EOF
+		}
+
+		event := r.events[0]
+		if event.Dir != RX {
+			return 0, timeoutError{}
+		}
+		r.events = r.events[1:]
+		r.pending = event.Data
+
+		if len(r.pending) == 0 && event.Error != "" {
+			return 0, replayError(event.Error)
+		}
+	}
+
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+// Write implements io.Writer, failing with ErrReplayMismatch unless p is what
+// was transmitted next in the capture. Anything received but not read before
+// then is discarded.
+func (r *Replayer) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pending = nil
+	for len(r.events) > 0 && r.events[0].Dir == RX {
+		r.events = r.events[1:]
+	}
+
+	if len(r.events) == 0 {
+		return 0, io.ErrClosedPipe
+	}
+
+	event := r.events[0]
+	if !bytes.Equal(p, event.Data) {
+		return 0, fmt.Errorf("%w: wrote % X expected % X", ErrReplayMismatch, p, []byte(event.Data))
+	}
+	r.events = r.events[1:]
+
+	if event.Error != "" {
+		return len(p), replayError(event.Error)
+	}
+	return len(p), nil
+}
+
+// Remaining returns the number of events yet to be replayed
+func (r *Replayer) Remaining() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.events)
+}
+
+// Close implements io.Closer
+func (r *Replayer) Close() error {
+	return nil
+}
+
+// SetReadDeadline is accepted so that the aurora package drains and times out
+// as it would have when the capture was made, a replay never blocks
+func (r *Replayer) SetReadDeadline(t time.Time) error {
+	return nil
+}
+
+// SetWriteDeadline is accepted for the same reason as SetReadDeadline
+func (r *Replayer) SetWriteDeadline(t time.Time) error {
+	return nil
+}
+
+func replayError(text string) error {
+	switch text {
+	case CaptureTimeout:
+		return timeoutError{}
+	case CaptureEOF:
+		return io.EOF
+	}
+	return errors.New(text)
+}
+
+// timeoutError is returned when a replayed read times out
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
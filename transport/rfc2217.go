@@ -0,0 +1,181 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package transport
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"time"
+)
+
+// Telnet commands and options needed for RFC2217
+const (
+	telnetSE   = 240
+	telnetSB   = 250
+	telnetWILL = 251
+	telnetWONT = 252
+	telnetDO   = 253
+	telnetDONT = 254
+	telnetIAC  = 255
+
+	optionBinary          = 0
+	optionSuppressGoAhead = 3
+	optionComPort         = 44
+)
+
+// RFC2217 COM-PORT-OPTION client to server commands
+const (
+	comPortSetBaudRate = 1
+	comPortSetDataSize = 2
+	comPortSetParity   = 3
+	comPortSetStopSize = 4
+)
+
+// telnet holds the state of the telnet protocol for an RFC2217 connection
+type telnet struct {
+	opts  *Options
+	state int
+	verb  byte
+}
+
+// States of the telnet parser
+const (
+	telnetData = iota
+	telnetCommand
+	telnetOption
+	telnetSubnegotiation
+	telnetSubnegotiationIAC
+)
+
+// DialRFC2217 connects to a gateway speaking RFC2217, telnet with the COM port
+// control option, negotiating the baud rate, data bits, parity and stop bits of
+// the serial line from the options
+func DialRFC2217(addr string, opts *Options) (*Conn, error) {
+	c := &Conn{
+		addr:    addr,
+		timeout: opts.Timeout,
+		telnet:  &telnet{opts: opts},
+	}
+
+	if _, err := c.get(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (t *telnet) reset() {
+	t.state = telnetData
+}
+
+// negotiate enables binary transmission and configures the serial line
+func (t *telnet) negotiate(w io.Writer) error {
+	// The deadline is reset once the connection is established
+	if d, ok := w.(interface{ SetWriteDeadline(time.Time) error }); ok {
+		d.SetWriteDeadline(time.Now().Add(t.opts.Timeout))
+	}
+
+	buf := []byte{
+		telnetIAC, telnetWILL, optionBinary,
+		telnetIAC, telnetDO, optionBinary,
+		telnetIAC, telnetWILL, optionComPort,
+	}
+
+	baud := make([]byte, 4)
+	binary.BigEndian.PutUint32(baud, uint32(t.opts.Baud))
+	buf = append(buf, subnegotiation(comPortSetBaudRate, baud...)...)
+	buf = append(buf, subnegotiation(comPortSetDataSize, byte(t.opts.DataBits))...)
+	// RFC2217 counts parities from 1, none being the first
+	buf = append(buf, subnegotiation(comPortSetParity, byte(t.opts.Parity)+1)...)
+	buf = append(buf, subnegotiation(comPortSetStopSize, byte(t.opts.StopBits))...)
+
+	_, err := w.Write(buf)
+	return err
+}
+
+// subnegotiation returns a COM-PORT-OPTION subnegotiation for the command
+func subnegotiation(command byte, value ...byte) []byte {
+	buf := []byte{telnetIAC, telnetSB, optionComPort, command}
+	buf = append(buf, escape(value)...)
+	return append(buf, telnetIAC, telnetSE)
+}
+
+// filter strips telnet commands from the data read in place, replying to any
+// option negotiation as required, and returns the length of data remaining
+func (t *telnet) filter(w io.Writer, buf []byte) int {
+	n := 0
+	for _, ch := range buf {
+		switch t.state {
+		case telnetData:
+			if ch == telnetIAC {
+				t.state = telnetCommand
+				continue
+			}
+			buf[n] = ch
+			n++
+		case telnetCommand:
+			switch ch {
+			case telnetIAC:
+				buf[n] = ch
+				n++
+				t.state = telnetData
+			case telnetWILL, telnetWONT, telnetDO, telnetDONT:
+				t.verb = ch
+				t.state = telnetOption
+			case telnetSB:
+				t.state = telnetSubnegotiation
+			default:
+				t.state = telnetData
+			}
+		case telnetOption:
+			t.reply(w, t.verb, ch)
+			t.state = telnetData
+		case telnetSubnegotiation:
+			// Notifications from the gateway, such as line state, are of no interest
+			if ch == telnetIAC {
+				t.state = telnetSubnegotiationIAC
+			}
+		case telnetSubnegotiationIAC:
+			if ch == telnetSE {
+				t.state = telnetData
+			} else {
+				t.state = telnetSubnegotiation
+			}
+		}
+	}
+
+	return n
+}
+
+// reply refuses any option other than those needed for RFC2217
+func (t *telnet) reply(w io.Writer, verb, option byte) {
+	switch option {
+	case optionBinary, optionSuppressGoAhead, optionComPort:
+		return
+	}
+
+	switch verb {
+	case telnetDO:
+		w.Write([]byte{telnetIAC, telnetWONT, option})
+	case telnetWILL:
+		w.Write([]byte{telnetIAC, telnetDONT, option})
+	}
+}
+
+// escape doubles any IAC bytes so they are sent as data
+func escape(b []byte) []byte {
+	if bytes.IndexByte(b, telnetIAC) < 0 {
+		return b
+	}
+
+	buf := make([]byte, 0, len(b)+4)
+	for _, ch := range b {
+		buf = append(buf, ch)
+		if ch == telnetIAC {
+			buf = append(buf, telnetIAC)
+		}
+	}
+	return buf
+}
@@ -0,0 +1,133 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+//go:build linux
+// +build linux
+
+package transport
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// cmspar selects mark or space parity along with PARENB, it's missing from syscall
+const cmspar = 0x40000000
+
+var serialBauds = map[int]uint32{
+	1200:   syscall.B1200,
+	2400:   syscall.B2400,
+	4800:   syscall.B4800,
+	9600:   syscall.B9600,
+	19200:  syscall.B19200,
+	38400:  syscall.B38400,
+	57600:  syscall.B57600,
+	115200: syscall.B115200,
+}
+
+var serialParities = map[Parity]uint32{
+	ParityNone:  0,
+	ParityOdd:   syscall.PARENB | syscall.PARODD,
+	ParityEven:  syscall.PARENB,
+	ParityMark:  syscall.PARENB | syscall.PARODD | cmspar,
+	ParitySpace: syscall.PARENB | cmspar,
+}
+
+var serialDataBits = map[int]uint32{
+	5: syscall.CS5,
+	6: syscall.CS6,
+	7: syscall.CS7,
+	8: syscall.CS8,
+}
+
+// serialPort is a tty opened through the runtime poller, so unlike the likes of
+// tarm/serial it supports the read and write deadlines the aurora package uses
+// to time out, drain and resynchronise. A ReadTimeout caps every read, even one
+// without a deadline.
+type serialPort struct {
+	*os.File
+	readTimeout time.Duration
+
+	mu           sync.Mutex
+	readDeadline time.Time
+}
+
+func openSerial(name string, opts *Options) (io.ReadWriteCloser, error) {
+	t := syscall.Termios{
+		Cflag: syscall.CREAD | syscall.CLOCAL,
+	}
+
+	baud, ok := serialBauds[opts.Baud]
+	if !ok {
+		return nil, fmt.Errorf("transport: unsupported baud rate %d", opts.Baud)
+	}
+	t.Cflag |= baud
+
+	size, ok := serialDataBits[opts.DataBits]
+	if !ok {
+		return nil, fmt.Errorf("transport: unsupported data bits %d", opts.DataBits)
+	}
+	t.Cflag |= size | serialParities[opts.Parity]
+
+	switch opts.StopBits {
+	case 1:
+	case 2:
+		t.Cflag |= syscall.CSTOPB
+	default:
+		return nil, fmt.Errorf("transport: unsupported stop bits %d", opts.StopBits)
+	}
+
+	t.Cc[syscall.VMIN] = 1
+	t.Cc[syscall.VTIME] = 0
+
+	// Non-blocking so the open doesn't wait on carrier detect, and so the file
+	// is registered with the poller and supports deadlines
+	f, err := os.OpenFile(name, os.O_RDWR|syscall.O_NOCTTY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), syscall.TCSETS, uintptr(unsafe.Pointer(&t))); errno != 0 {
+		f.Close()
+		return nil, &os.PathError{Op: "tcsets", Path: name, Err: errno}
+	}
+
+	return &serialPort{File: f, readTimeout: opts.ReadTimeout}, nil
+}
+
+// Read implements io.Reader, giving up at the read deadline or after ReadTimeout
+// whichever is sooner
+func (p *serialPort) Read(b []byte) (int, error) {
+	if p.readTimeout > 0 {
+		p.mu.Lock()
+		deadline := time.Now().Add(p.readTimeout)
+		if !p.readDeadline.IsZero() && p.readDeadline.Before(deadline) {
+			deadline = p.readDeadline
+		}
+		p.File.SetReadDeadline(deadline)
+		p.mu.Unlock()
+	}
+	return p.File.Read(b)
+}
+
+// SetReadDeadline sets the deadline for future and pending reads
+func (p *serialPort) SetReadDeadline(t time.Time) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.readDeadline = t
+	return p.File.SetReadDeadline(t)
+}
+
+// SetDeadline sets both the read and write deadlines
+func (p *serialPort) SetDeadline(t time.Time) error {
+	if err := p.SetReadDeadline(t); err != nil {
+		return err
+	}
+	return p.File.SetWriteDeadline(t)
+}
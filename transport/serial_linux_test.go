@@ -0,0 +1,109 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+//go:build linux
+// +build linux
+
+package transport_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/freman/go-aurora/transport"
+)
+
+// openPty returns the master end of a new pseudo-terminal and the path of its slave
+func openPty(t *testing.T) (*os.File, string) {
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		t.Skipf("No pseudo-terminals: %v", err)
+	}
+
+	var unlock int32
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, master.Fd(), syscall.TIOCSPTLCK, uintptr(unsafe.Pointer(&unlock))); errno != 0 {
+		master.Close()
+		t.Fatalf("unlockpt: %v", errno)
+	}
+
+	var n uint32
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, master.Fd(), syscall.TIOCGPTN, uintptr(unsafe.Pointer(&n))); errno != 0 {
+		master.Close()
+		t.Fatalf("ptsname: %v", errno)
+	}
+
+	return master, fmt.Sprintf("/dev/pts/%d", n)
+}
+
+type deadlineConn interface {
+	io.ReadWriteCloser
+	SetReadDeadline(t time.Time) error
+	SetWriteDeadline(t time.Time) error
+}
+
+func expectTimeout(t *testing.T, err error) {
+	t.Helper()
+	if !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("Expected %v got %v", os.ErrDeadlineExceeded, err)
+	}
+}
+
+func TestDialSerialDeadline(t *testing.T) {
+	master, name := openPty(t)
+	defer master.Close()
+
+	port, err := transport.Dial("serial://" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer port.Close()
+
+	conn, ok := port.(deadlineConn)
+	if !ok {
+		t.Fatalf("Expected %T to support deadlines", port)
+	}
+
+	conn.SetReadDeadline(time.Now().Add(20 * time.Millisecond))
+	start := time.Now()
+	_, err = conn.Read(make([]byte, 1))
+	expectTimeout(t, err)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the read to give up at the deadline, took %v", elapsed)
+	}
+
+	// A timeout shouldn't cost the port, and the line is raw
+	conn.SetReadDeadline(time.Time{})
+	sent := []byte{0x02, 0x3a, 0x0d, 0x0a, 0x11}
+	if _, err := master.Write(sent); err != nil {
+		t.Fatal(err)
+	}
+	got := make([]byte, len(sent))
+	if _, err := io.ReadFull(conn, got); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(sent, got) {
+		t.Errorf("Expected % X got % X", sent, got)
+	}
+}
+
+func TestDialSerialReadTimeout(t *testing.T) {
+	master, name := openPty(t)
+	defer master.Close()
+
+	port, err := transport.Dial("serial://" + name + "?readtimeout=20ms")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer port.Close()
+
+	_, err = port.Read(make([]byte, 1))
+	expectTimeout(t, err)
+}
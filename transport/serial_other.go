@@ -0,0 +1,37 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+//go:build !linux
+// +build !linux
+
+package transport
+
+import (
+	"io"
+
+	"github.com/tarm/serial"
+)
+
+var serialParities = map[Parity]serial.Parity{
+	ParityNone:  serial.ParityNone,
+	ParityOdd:   serial.ParityOdd,
+	ParityEven:  serial.ParityEven,
+	ParityMark:  serial.ParityMark,
+	ParitySpace: serial.ParitySpace,
+}
+
+// openSerial opens the serial port, which doesn't support deadlines on this
+// platform so opts.ReadTimeout is the only way to stop a read blocking forever
+func openSerial(name string, opts *Options) (io.ReadWriteCloser, error) {
+	config := &serial.Config{
+		Name:        name,
+		Baud:        opts.Baud,
+		Size:        byte(opts.DataBits),
+		Parity:      serialParities[opts.Parity],
+		StopBits:    serial.StopBits(opts.StopBits),
+		ReadTimeout: opts.ReadTimeout,
+	}
+
+	return serial.OpenPort(config)
+}
@@ -0,0 +1,181 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package transport
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+// ErrClosed is returned when using a Conn that has been closed
+var ErrClosed = errors.New("Use of closed connection")
+
+// Conn is a connection to a serial-to-Ethernet gateway. Should the connection
+// drop it is redialled on the next read or write, with any deadlines carried
+// over to the new connection.
+type Conn struct {
+	addr    string
+	timeout time.Duration
+	telnet  *telnet // Set for RFC2217 gateways
+
+	mu            sync.Mutex
+	conn          net.Conn
+	closed        bool
+	readDeadline  time.Time
+	writeDeadline time.Time
+}
+
+// DialTCP connects to a gateway that passes the serial line through as a raw
+// TCP stream, as most serial servers do in their "TCP server" mode
+func DialTCP(addr string, timeout time.Duration) (*Conn, error) {
+	c := &Conn{
+		addr:    addr,
+		timeout: timeout,
+	}
+
+	if _, err := c.get(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// get returns the current connection, dialling a new one if need be
+func (c *Conn) get() (net.Conn, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil, ErrClosed
+	}
+	if c.conn != nil {
+		return c.conn, nil
+	}
+
+	conn, err := net.DialTimeout("tcp", c.addr, c.timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.telnet != nil {
+		c.telnet.reset()
+		if err := c.telnet.negotiate(conn); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	conn.SetReadDeadline(c.readDeadline)
+	conn.SetWriteDeadline(c.writeDeadline)
+	c.conn = conn
+	return conn, nil
+}
+
+// drop closes the connection if it failed with something other than a timeout,
+// so the next read or write dials afresh
+func (c *Conn) drop(conn net.Conn, err error) {
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn == conn {
+		conn.Close()
+		c.conn = nil
+	}
+}
+
+// Read implements io.Reader
+func (c *Conn) Read(b []byte) (int, error) {
+	for {
+		conn, err := c.get()
+		if err != nil {
+			return 0, err
+		}
+
+		n, err := conn.Read(b)
+		if c.telnet != nil {
+			n = c.telnet.filter(conn, b[:n])
+		}
+		if err != nil {
+			c.drop(conn, err)
+			return n, err
+		}
+
+		// Nothing but telnet negotiation, keep reading
+		if n > 0 || len(b) == 0 {
+			return n, nil
+		}
+	}
+}
+
+// Write implements io.Writer
+func (c *Conn) Write(b []byte) (int, error) {
+	conn, err := c.get()
+	if err != nil {
+		return 0, err
+	}
+
+	buf := b
+	if c.telnet != nil {
+		buf = escape(b)
+	}
+
+	if _, err := conn.Write(buf); err != nil {
+		c.drop(conn, err)
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// Close closes the connection, it won't be redialled
+func (c *Conn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = true
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
+
+// SetReadDeadline sets the read deadline of the current connection and any
+// that replace it
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.readDeadline = t
+	if c.conn != nil {
+		return c.conn.SetReadDeadline(t)
+	}
+	return nil
+}
+
+// SetWriteDeadline sets the write deadline of the current connection and any
+// that replace it
+func (c *Conn) SetWriteDeadline(t time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.writeDeadline = t
+	if c.conn != nil {
+		return c.conn.SetWriteDeadline(t)
+	}
+	return nil
+}
+
+// SetDeadline sets both the read and write deadlines
+func (c *Conn) SetDeadline(t time.Time) error {
+	if err := c.SetReadDeadline(t); err != nil {
+		return err
+	}
+	return c.SetWriteDeadline(t)
+}
@@ -0,0 +1,141 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+/*
+Package transport opens the connections used to reach an RS485 bus of Aurora
+inverters, be it a local serial port or a serial-to-Ethernet gateway.
+
+Connections are described by URL:
+
+	serial:///dev/ttyUSB0?baud=19200&parity=none
+	tcp://10.0.0.5:4001
+	rfc2217://10.0.0.5:4001?baud=19200&parity=none
+
+A bare path such as /dev/ttyUSB0 is taken to be a serial port. The tcp and
+rfc2217 transports reconnect on demand should the connection drop. Every
+transport supports read and write deadlines so the aurora package can time out
+and resynchronise, other than serial ports on platforms besides Linux which only
+have the readtimeout option to stop a read blocking forever.
+*/
+package transport
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Defaults used when the URL doesn't specify otherwise
+const (
+	DefaultBaud        = 19200
+	DefaultDialTimeout = 5 * time.Second
+)
+
+// ErrUnsupportedScheme is returned by Dial for URLs it doesn't know how to open
+var ErrUnsupportedScheme = errors.New("Unsupported transport scheme")
+
+// Parity is the parity used on a serial line
+type Parity byte
+
+// Available parities
+const (
+	ParityNone Parity = iota
+	ParityOdd
+	ParityEven
+	ParityMark
+	ParitySpace
+)
+
+var parityNames = map[string]Parity{
+	"none":  ParityNone,
+	"odd":   ParityOdd,
+	"even":  ParityEven,
+	"mark":  ParityMark,
+	"space": ParitySpace,
+}
+
+// Options holds the settings parsed from the query of a URL
+type Options struct {
+	Baud        int           // Baud rate, default 19200
+	Parity      Parity        // Parity, default none
+	DataBits    int           // Data bits, default 8
+	StopBits    int           // Stop bits, default 1
+	Timeout     time.Duration // Dial timeout for network transports, default 5s
+	ReadTimeout time.Duration // Longest a read of a serial port may block, default no limit
+}
+
+// Dial opens the connection described by the given URL
+func Dial(rawurl string) (io.ReadWriteCloser, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := ParseOptions(u.Query())
+	if err != nil {
+		return nil, err
+	}
+
+	var conn *Conn
+	switch strings.ToLower(u.Scheme) {
+	case "", "serial":
+		return openSerial(u.Host+u.Path, opts)
+	case "tcp":
+		conn, err = DialTCP(u.Host, opts.Timeout)
+	case "rfc2217", "telnet":
+		conn, err = DialRFC2217(u.Host, opts)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedScheme, u.Scheme)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+// ParseOptions parses baud, parity, databits, stopbits, timeout and readtimeout
+// from the query of a URL, applying defaults for any that are missing
+func ParseOptions(query url.Values) (*Options, error) {
+	opts := &Options{
+		Baud:     DefaultBaud,
+		DataBits: 8,
+		StopBits: 1,
+		Timeout:  DefaultDialTimeout,
+	}
+
+	var err error
+	for key, values := range query {
+		value := values[0]
+		switch strings.ToLower(key) {
+		case "baud":
+			opts.Baud, err = strconv.Atoi(value)
+		case "databits":
+			opts.DataBits, err = strconv.Atoi(value)
+		case "stopbits":
+			opts.StopBits, err = strconv.Atoi(value)
+		case "timeout":
+			opts.Timeout, err = time.ParseDuration(value)
+		case "readtimeout":
+			opts.ReadTimeout, err = time.ParseDuration(value)
+		case "parity":
+			var ok bool
+			if opts.Parity, ok = parityNames[strings.ToLower(value)]; !ok {
+				err = fmt.Errorf("unknown parity %q", value)
+			}
+		default:
+			err = fmt.Errorf("unknown option %q", key)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("transport: %s: %v", key, err)
+		}
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,212 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package transport_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/freman/go-aurora/transport"
+)
+
+func TestParseOptions(t *testing.T) {
+	query, _ := url.ParseQuery("baud=9600&parity=even&timeout=2s")
+	opts, err := transport.ParseOptions(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &transport.Options{
+		Baud:     9600,
+		Parity:   transport.ParityEven,
+		DataBits: 8,
+		StopBits: 1,
+		Timeout:  2 * time.Second,
+	}
+	if !reflect.DeepEqual(expected, opts) {
+		t.Errorf("Expected %+v got %+v", expected, opts)
+	}
+
+	for _, bad := range []string{"baud=fast", "parity=maybe", "colour=blue"} {
+		query, _ := url.ParseQuery(bad)
+		if _, err := transport.ParseOptions(query); err == nil {
+			t.Errorf("Expected error parsing %s", bad)
+		}
+	}
+}
+
+func TestDialUnsupported(t *testing.T) {
+	_, err := transport.Dial("carrier-pigeon://loft")
+	if !errors.Is(err, transport.ErrUnsupportedScheme) {
+		t.Errorf("Expected %v got %v", transport.ErrUnsupportedScheme, err)
+	}
+}
+
+func listen(t *testing.T) net.Listener {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+func TestDialTCPReconnect(t *testing.T) {
+	l := listen(t)
+	defer l.Close()
+
+	go func() {
+		// First connection echoes once then hangs up, the second echoes forever
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		buf := make([]byte, 4)
+		io.ReadFull(conn, buf)
+		conn.Write(buf)
+		conn.Close()
+
+		conn, err = l.Accept()
+		if err != nil {
+			return
+		}
+		io.Copy(conn, conn)
+	}()
+
+	conn, err := transport.Dial("tcp://" + l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	roundTrip := func(data []byte) error {
+		if _, err := conn.Write(data); err != nil {
+			return err
+		}
+		buf := make([]byte, len(data))
+		if _, err := io.ReadFull(conn, buf); err != nil {
+			return err
+		}
+		if !bytes.Equal(data, buf) {
+			t.Errorf("Expected % X got % X", data, buf)
+		}
+		return nil
+	}
+
+	if err := roundTrip([]byte{1, 2, 3, 4}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The gateway hung up, the read notices and the next write redials
+	if _, err := conn.Read(make([]byte, 1)); err == nil {
+		t.Error("Expected error reading from closed connection")
+	}
+	if err := roundTrip([]byte{5, 6, 7, 8}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDialTCPDeadline(t *testing.T) {
+	l := listen(t)
+	defer l.Close()
+
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		io.Copy(io.Discard, conn)
+	}()
+
+	conn, err := transport.DialTCP(l.Addr().String(), time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	conn.SetReadDeadline(time.Now().Add(20 * time.Millisecond))
+	_, err = conn.Read(make([]byte, 1))
+	if netErr, ok := err.(net.Error); !ok || !netErr.Timeout() {
+		t.Errorf("Expected timeout got %v", err)
+	}
+
+	// A timeout shouldn't cost the connection
+	if _, err := conn.Write([]byte{1}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDialRFC2217(t *testing.T) {
+	l := listen(t)
+	defer l.Close()
+
+	negotiation := []byte{
+		255, 251, 0, // WILL BINARY
+		255, 253, 0, // DO BINARY
+		255, 251, 44, // WILL COM-PORT-OPTION
+		255, 250, 44, 1, 0, 0, 37, 128, 255, 240, // SET-BAUDRATE 9600
+		255, 250, 44, 2, 8, 255, 240, // SET-DATASIZE 8
+		255, 250, 44, 3, 3, 255, 240, // SET-PARITY EVEN
+		255, 250, 44, 4, 1, 255, 240, // SET-STOPSIZE 1
+	}
+
+	received := make(chan []byte, 2)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		buf := make([]byte, len(negotiation))
+		io.ReadFull(conn, buf)
+		received <- buf
+
+		conn.Write([]byte{
+			255, 253, 44, // DO COM-PORT-OPTION, accepted quietly
+			255, 251, 1, // WILL ECHO, should be refused
+			1, 255, 255, 2, // Data with an escaped IAC
+			255, 250, 44, 107, 0, 255, 240, // NOTIFY-MODEMSTATE, ignored
+			3,
+		})
+
+		buf = make([]byte, 6)
+		io.ReadFull(conn, buf)
+		received <- buf
+	}()
+
+	conn, err := transport.Dial("rfc2217://" + l.Addr().String() + "?baud=9600&parity=even")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if buf := <-received; !bytes.Equal(negotiation, buf) {
+		t.Errorf("Expected negotiation % X got % X", negotiation, buf)
+	}
+
+	buf := make([]byte, 4)
+	if _, err := io.ReadFull(conn, buf); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []byte{1, 255, 2, 3}; !bytes.Equal(expected, buf) {
+		t.Errorf("Expected % X got % X", expected, buf)
+	}
+
+	conn.Write([]byte{255, 4, 5})
+	expected := []byte{
+		255, 254, 1, // DONT ECHO
+		255, 255, 4, 5,
+	}
+	if buf := <-received; !bytes.Equal(expected[:6], buf) {
+		t.Errorf("Expected % X got % X", expected[:6], buf)
+	}
+}
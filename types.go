@@ -6,6 +6,15 @@ package aurora
 
 import "fmt"
 
+// InverterEpochOffset is the number of seconds since unix epoch that
+// Power-One started the Aurora firmware epoch
+const InverterEpochOffset = 946706400
+
+// ALarmVrefError is the misspelt name AlarmVrefError was once known by.
+//
+// Deprecated: Use AlarmVrefError.
+const ALarmVrefError = AlarmVrefError
+
 // Argument is an interface that exposes Byte() to return a single byte
 // representation of the given argument
 type Argument interface {
@@ -39,16 +48,17 @@ func (v *Version) String() string {
 	return fmt.Sprintf("Model: %s, Regulation: %s, Transformer: %s, Type: %s", v.Model, v.Regulation, v.Transformer, v.Type)
 }
 
-// outputPayload is a convenient struct for writing binary to the inverter
-type outputPayload struct {
-	Payload [8]byte
-	CRC     uint16
+// Flags holds the flags and switches returned by the Flags() func
+type Flags struct {
+	Flag1   byte
+	Flag2   byte
+	Switch1 byte
+	Switch2 byte
 }
 
-// inputPayload is a convenient struct for reading binary from the inverter
-type inputPayload struct {
-	Payload [6]byte
-	CRC     uint16
+// String returns the flags as an easy to read string of bits
+func (f *Flags) String() string {
+	return fmt.Sprintf("Flag1: %08b, Flag2: %08b, Switch1: %08b, Switch2: %08b", f.Flag1, f.Flag2, f.Switch1, f.Switch2)
 }
 
 // Byte is a concrete Argument
@@ -120,16 +130,32 @@ func (d DSParameter) Byte() byte {
 	return byte(d)
 }
 
-func (o *outputPayload) String() string {
-	return fmt.Sprintf("% X (%d)", o.Payload, o.CRC)
+func (c Command) String() string {
+	if str, ok := commandStrings[c]; ok {
+		return str
+	}
+
+	return fmt.Sprintf("Unknown Command(%d)", byte(c))
 }
 
-func (i *inputPayload) String() string {
-	return fmt.Sprintf("% X (%d)", i.Payload, i.CRC)
+func (c Counter) String() string {
+	if str, ok := counterStrings[c]; ok {
+		return str
+	}
+
+	return fmt.Sprintf("Unknown Counter(%d)", byte(c))
+}
+
+func (c CumulationPeriod) String() string {
+	if str, ok := cumulationPeriodStrings[c]; ok {
+		return str
+	}
+
+	return fmt.Sprintf("Unknown CumulationPeriod(%d)", byte(c))
 }
 
 func (t TransmissionState) String() string {
-	if str, ok := transmissionStates[t]; ok {
+	if str, ok := transmissionStateStrings[t]; ok {
 		return str
 	}
 
@@ -137,7 +163,7 @@ func (t TransmissionState) String() string {
 }
 
 func (a AlarmState) String() string {
-	if str, ok := alarmStates[a]; ok {
+	if str, ok := alarmStateStrings[a]; ok {
 		return str
 	}
 
@@ -149,7 +175,7 @@ func (a AlarmStates) String() string {
 }
 
 func (g GlobalState) String() string {
-	if str, ok := globalStates[g]; ok {
+	if str, ok := globalStateStrings[g]; ok {
 		return str
 	}
 
@@ -157,7 +183,7 @@ func (g GlobalState) String() string {
 }
 
 func (c ConfigurationState) String() string {
-	if str, ok := configStates[c]; ok {
+	if str, ok := configurationStateStrings[c]; ok {
 		return str
 	}
 
@@ -165,7 +191,7 @@ func (c ConfigurationState) String() string {
 }
 
 func (i InverterState) String() string {
-	if str, ok := inverterStates[i]; ok {
+	if str, ok := inverterStateStrings[i]; ok {
 		return str
 	}
 
@@ -173,7 +199,7 @@ func (i InverterState) String() string {
 }
 
 func (d DCDCState) String() string {
-	if str, ok := dcdcStates[d]; ok {
+	if str, ok := dcdcStateStrings[d]; ok {
 		return str
 	}
 
@@ -188,28 +214,28 @@ func (d DSParameter) String() string {
 }
 
 func (p Product) String() string {
-	if str, ok := productNames[p]; ok {
+	if str, ok := productStrings[p]; ok {
 		return str
 	}
 	return fmt.Sprintf("Unknown Product (%d)", byte(p))
 }
 
 func (p ProductSpec) String() string {
-	if str, ok := productSpecs[p]; ok {
+	if str, ok := productSpecStrings[p]; ok {
 		return str
 	}
 	return fmt.Sprintf("Unknown ProductSpec (%d)", byte(p))
 }
 
 func (i InverterType) String() string {
-	if str, ok := inverterTypes[i]; ok {
+	if str, ok := inverterTypeStrings[i]; ok {
 		return str
 	}
 	return fmt.Sprintf("Unknown InverterType (%d)", byte(i))
 }
 
 func (m InputType) String() string {
-	if str, ok := inputTypes[m]; ok {
+	if str, ok := inputTypeStrings[m]; ok {
 		return str
 	}
 	return fmt.Sprintf("Unknown InputType (%d)", byte(m))
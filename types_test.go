@@ -62,11 +62,25 @@ func TestTransmissionStateString(t *testing.T) {
 		t.Errorf("Unexpected string returned: %s", str)
 	}
 
+	if str := aurora.TransmissionState(55).String(); str != "Not toggled service mode" {
+		t.Errorf("Unexpected string returned: %s", str)
+	}
+
 	if str := aurora.TransmissionState(99).String(); str != "Unknown TransmissionState(99)" {
 		t.Errorf("Unexpected string returned: %s", str)
 	}
 }
 
+func TestCommandString(t *testing.T) {
+	if str := aurora.GetDSP.String(); str != "Measure Request to the DSP" {
+		t.Errorf("Unexpected string returned: %s", str)
+	}
+
+	if str := aurora.Command(99).String(); str != "Unknown Command(99)" {
+		t.Errorf("Unexpected string returned: %s", str)
+	}
+}
+
 func TestDCDCStateString(t *testing.T) {
 	if str := aurora.DCDCState(0).String(); str != "DcDc OFF" {
 		t.Errorf("Unexpected string returned: %s", str)
@@ -136,3 +150,49 @@ func TestConfigurationStateString(t *testing.T) {
 		t.Errorf("Unexpected string returned: %s", str)
 	}
 }
+
+func TestCounterString(t *testing.T) {
+	if str := aurora.CounterGrid.String(); str != "Grid Connection Time" {
+		t.Errorf("Unexpected string returned: %s", str)
+	}
+
+	if str := aurora.Counter(99).String(); str != "Unknown Counter(99)" {
+		t.Errorf("Unexpected string returned: %s", str)
+	}
+}
+
+func TestCumulationPeriodString(t *testing.T) {
+	if str := aurora.CumulatedMonthly.String(); str != "Monthly" {
+		t.Errorf("Unexpected string returned: %s", str)
+	}
+
+	if str := aurora.CumulationPeriod(99).String(); str != "Unknown CumulationPeriod(99)" {
+		t.Errorf("Unexpected string returned: %s", str)
+	}
+}
+
+func TestByName(t *testing.T) {
+	if v, ok := aurora.CommandByName("get_last_4_alarms"); !ok || v != aurora.GetLast4Alarms {
+		t.Errorf("Expected %v got %v", aurora.GetLast4Alarms, v)
+	}
+
+	if v, ok := aurora.DSParameterByName("riferimento_anello_bulk"); !ok || v != aurora.DSPRiferimentoAnelloBulk {
+		t.Errorf("Expected %v got %v", aurora.DSPRiferimentoAnelloBulk, v)
+	}
+
+	if v, ok := aurora.GlobalStateByName("run"); !ok || v != aurora.GSRun {
+		t.Errorf("Expected %v got %v", aurora.GSRun, v)
+	}
+
+	if v, ok := aurora.AlarmStateByName("vref_error"); !ok || v != aurora.AlarmVrefError {
+		t.Errorf("Expected %v got %v", aurora.AlarmVrefError, v)
+	}
+
+	if v, ok := aurora.ProductByName("3_6kw_outdoor"); !ok || v != aurora.Product3_6kWOutdoor {
+		t.Errorf("Expected %v got %v", aurora.Product3_6kWOutdoor, v)
+	}
+
+	if _, ok := aurora.GlobalStateByName("Run"); ok {
+		t.Error("Expected names to be case sensitive")
+	}
+}
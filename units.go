@@ -0,0 +1,209 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package aurora
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// UnitOfMeasure is the unit a measurement is in
+type UnitOfMeasure byte
+
+// Units of measurement
+const (
+	UnitNone UnitOfMeasure = iota
+	UnitWatts
+	UnitVolts
+	UnitAmps
+	UnitHertz
+	UnitCelsius
+	UnitWattHours
+	UnitOhms
+	UnitMegohms // Isolation resistance is reported in megohms
+	UnitRPM
+	UnitJoules
+	UnitSeconds
+)
+
+var unitSymbols = map[UnitOfMeasure]string{
+	UnitNone:      "",
+	UnitWatts:     "W",
+	UnitVolts:     "V",
+	UnitAmps:      "A",
+	UnitHertz:     "Hz",
+	UnitCelsius:   "°C",
+	UnitWattHours: "Wh",
+	UnitOhms:      "Ω",
+	UnitMegohms:   "MΩ",
+	UnitRPM:       "rpm",
+	UnitJoules:    "J",
+	UnitSeconds:   "s",
+}
+
+var unitStrings = map[UnitOfMeasure]string{
+	UnitNone:      "None",
+	UnitWatts:     "Watts",
+	UnitVolts:     "Volts",
+	UnitAmps:      "Amps",
+	UnitHertz:     "Hertz",
+	UnitCelsius:   "Celsius",
+	UnitWattHours: "Watt Hours",
+	UnitOhms:      "Ohms",
+	UnitMegohms:   "Megohms",
+	UnitRPM:       "RPM",
+	UnitJoules:    "Joules",
+	UnitSeconds:   "Seconds",
+}
+
+func (u UnitOfMeasure) String() string {
+	if str, ok := unitStrings[u]; ok {
+		return str
+	}
+	return fmt.Sprintf("Unknown UnitOfMeasure(%d)", u)
+}
+
+// Symbol returns the symbol of the unit such as W or Hz, empty if there isn't one
+func (u UnitOfMeasure) Symbol() string {
+	return unitSymbols[u]
+}
+
+// Format returns the value followed by the symbol of the unit
+func (u UnitOfMeasure) Format(v float64) string {
+	str := strconv.FormatFloat(v, 'f', -1, 32)
+	if symbol := u.Symbol(); symbol != "" {
+		return str + " " + symbol
+	}
+	return str
+}
+
+// MarshalText implements encoding.TextMarshaler, the unit is its symbol
+func (u UnitOfMeasure) MarshalText() ([]byte, error) {
+	if _, ok := unitSymbols[u]; !ok {
+		return nil, fmt.Errorf("Unknown UnitOfMeasure(%d)", u)
+	}
+	return []byte(u.Symbol()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (u *UnitOfMeasure) UnmarshalText(text []byte) error {
+	for unit, symbol := range unitSymbols {
+		if symbol == string(text) {
+			*u = unit
+			return nil
+		}
+	}
+	return fmt.Errorf("Unknown unit %q", text)
+}
+
+// Unit returns the unit the DSP reports the parameter in, UnitNone if unknown
+func (p DSParameter) Unit() UnitOfMeasure {
+	return dsParameterUnits[p]
+}
+
+// Measurement is a value along with the unit it is in, as decoded from a DSP
+// value or returned by Inverter.Read
+type Measurement struct {
+	Value float64
+	Unit  UnitOfMeasure
+}
+
+func (m Measurement) String() string {
+	return m.Unit.Format(m.Value)
+}
+
+// MarshalJSON implements json.Marshaler as {"value": ..., "unit": "..."}, values
+// that came from a float32 are written as short as they were read
+func (m Measurement) MarshalJSON() ([]byte, error) {
+	bits := 64
+	if float64(float32(m.Value)) == m.Value {
+		bits = 32
+	}
+	return marshalReading(json.Number(strconv.FormatFloat(m.Value, 'f', -1, bits)), m.Unit, nil)
+}
+
+// Watts is power
+type Watts float32
+
+// Volts is electric potential
+type Volts float32
+
+// Amps is electric current
+type Amps float32
+
+// Hertz is frequency
+type Hertz float32
+
+// Celsius is temperature in degrees celsius
+type Celsius float32
+
+// Ohms is electrical resistance
+type Ohms float32
+
+// RPM is the speed of a fan in revolutions per minute
+type RPM float32
+
+// WattHours is energy as cumulated by the inverter
+type WattHours uint32
+
+// Joules is energy, as exported over the last 10 seconds
+type Joules uint16
+
+func (w Watts) String() string     { return UnitWatts.Format(float64(w)) }
+func (v Volts) String() string     { return UnitVolts.Format(float64(v)) }
+func (a Amps) String() string      { return UnitAmps.Format(float64(a)) }
+func (h Hertz) String() string     { return UnitHertz.Format(float64(h)) }
+func (c Celsius) String() string   { return UnitCelsius.Format(float64(c)) }
+func (o Ohms) String() string      { return UnitOhms.Format(float64(o)) }
+func (r RPM) String() string       { return UnitRPM.Format(float64(r)) }
+func (e WattHours) String() string { return strconv.FormatUint(uint64(e), 10) + " Wh" }
+func (j Joules) String() string    { return UnitJoules.Format(float64(j)) }
+
+// Kilowatts returns the power in kilowatts
+func (w Watts) Kilowatts() float64 {
+	return float64(w) / 1000
+}
+
+// Watts returns the power of the given current at this potential
+func (v Volts) Watts(a Amps) Watts {
+	return Watts(float32(v) * float32(a))
+}
+
+// Fahrenheit returns the temperature in degrees fahrenheit
+func (c Celsius) Fahrenheit() float64 {
+	return float64(c)*9/5 + 32
+}
+
+// Megohms returns the resistance in megohms
+func (o Ohms) Megohms() float64 {
+	return float64(o) / 1e6
+}
+
+// Megohms returns the resistance given in megohms, as the DSP reports isolation
+// resistance
+func Megohms(m float32) Ohms {
+	return Ohms(float64(m) * 1e6)
+}
+
+// KilowattHours returns the energy in kilowatt hours
+func (e WattHours) KilowattHours() float64 {
+	return float64(e) / 1000
+}
+
+// Joules returns the energy in joules
+func (e WattHours) Joules() float64 {
+	return float64(e) * 3600
+}
+
+// WattHours returns the energy in watt hours
+func (j Joules) WattHours() float64 {
+	return float64(j) / 3600
+}
+
+// Average returns the average power over the 10 seconds the energy was exported in
+func (j Joules) Average() Watts {
+	return Watts(float32(j) / 10)
+}
@@ -0,0 +1,129 @@
+// Copyright 2016 Shannon Wynter. All rights reserved.
+// Use of this source code is governed by a MIT
+// license that can be found in the LICENSE file.
+
+package aurora_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/freman/go-aurora"
+)
+
+func TestUnitString(t *testing.T) {
+	tests := []struct {
+		value    fmt.Stringer
+		expected string
+	}{
+		{aurora.Watts(960), "960 W"},
+		{aurora.Volts(234.5), "234.5 V"},
+		{aurora.Amps(0.25), "0.25 A"},
+		{aurora.Hertz(49.98), "49.98 Hz"},
+		{aurora.Celsius(-3.5), "-3.5 °C"},
+		{aurora.Ohms(470), "470 Ω"},
+		{aurora.RPM(1200), "1200 rpm"},
+		{aurora.WattHours(432572318), "432572318 Wh"},
+		{aurora.Joules(82), "82 J"},
+		{aurora.UnitHertz, "Hertz"},
+		{aurora.UnitOfMeasure(99), "Unknown UnitOfMeasure(99)"},
+	}
+
+	for _, test := range tests {
+		if got := test.value.String(); got != test.expected {
+			t.Errorf("Expected %q got %q", test.expected, got)
+		}
+	}
+}
+
+func TestUnitConversion(t *testing.T) {
+	if kw := aurora.Watts(2500).Kilowatts(); kw != 2.5 {
+		t.Errorf("Expected 2.5 kW got %v", kw)
+	}
+	if w := aurora.Volts(240).Watts(2.5); w != 600 {
+		t.Errorf("Expected 600 W got %v", w)
+	}
+	if f := aurora.Celsius(100).Fahrenheit(); f != 212 {
+		t.Errorf("Expected 212 °F got %v", f)
+	}
+	if o := aurora.Megohms(2.5); o != 2500000 || o.Megohms() != 2.5 {
+		t.Errorf("Expected 2.5 MΩ got %v", o)
+	}
+	if kwh := aurora.WattHours(12345).KilowattHours(); kwh != 12.345 {
+		t.Errorf("Expected 12.345 kWh got %v", kwh)
+	}
+	if j := aurora.WattHours(2).Joules(); j != 7200 {
+		t.Errorf("Expected 7200 J got %v", j)
+	}
+	if wh := aurora.Joules(7200).WattHours(); wh != 2 {
+		t.Errorf("Expected 2 Wh got %v", wh)
+	}
+	if w := aurora.Joules(82).Average(); w != 8.2 {
+		t.Errorf("Expected 8.2 W got %v", w)
+	}
+}
+
+func TestDSParameterUnit(t *testing.T) {
+	for parameter, expected := range map[aurora.DSParameter]aurora.UnitOfMeasure{
+		aurora.DSPGridPower:           aurora.UnitWatts,
+		aurora.DSPGridVoltage:         aurora.UnitVolts,
+		aurora.DSPInput1Current:       aurora.UnitAmps,
+		aurora.DSPFrequency:           aurora.UnitHertz,
+		aurora.DSPBoosterTemperature:  aurora.UnitCelsius,
+		aurora.DSPIsolationResistance: aurora.UnitMegohms,
+		aurora.DSPFan1Speed:           aurora.UnitRPM,
+		aurora.DSParameter(255):       aurora.UnitNone,
+	} {
+		if got := parameter.Unit(); got != expected {
+			t.Errorf("Expected %v for %v got %v", expected, parameter, got)
+		}
+	}
+}
+
+func TestUnitJSON(t *testing.T) {
+	data, err := json.Marshal(map[string]aurora.UnitOfMeasure{"power": aurora.UnitWatts, "none": aurora.UnitNone})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"none":"","power":"W"}` {
+		t.Errorf("Unexpected %s", data)
+	}
+
+	var units map[string]aurora.UnitOfMeasure
+	if err := json.Unmarshal(data, &units); err != nil {
+		t.Fatal(err)
+	}
+	if units["power"] != aurora.UnitWatts || units["none"] != aurora.UnitNone {
+		t.Errorf("Unexpected %v", units)
+	}
+
+	if err := json.Unmarshal([]byte(`"furlongs"`), new(aurora.UnitOfMeasure)); err == nil {
+		t.Error("Expected error for an unknown unit")
+	}
+}
+
+func TestMeasurement(t *testing.T) {
+	m := aurora.Measurement{Value: float64(float32(230.1)), Unit: aurora.UnitVolts}
+	if str := m.String(); str != "230.1 V" {
+		t.Errorf("Expected %q got %q", "230.1 V", str)
+	}
+
+	tests := []struct {
+		measurement aurora.Measurement
+		expected    string
+	}{
+		{m, `{"value":230.1,"unit":"V"}`},
+		{aurora.Measurement{Value: 4000000001, Unit: aurora.UnitWattHours}, `{"value":4000000001,"unit":"Wh"}`},
+		{aurora.Measurement{Value: 0.5}, `{"value":0.5}`},
+	}
+	for _, test := range tests {
+		data, err := json.Marshal(test.measurement)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != test.expected {
+			t.Errorf("Expected %s got %s", test.expected, data)
+		}
+	}
+}